@@ -0,0 +1,291 @@
+package expect
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// AddrType is used for assertions about IP addresses and network prefixes.
+type AddrType struct {
+	addr     netip.Addr
+	prefix   netip.Prefix
+	isPrefix bool
+	assertion
+}
+
+// Addr creates an assertion about an IP address or a network prefix. IPv4-mapped IPv6 addresses
+// such as ::ffff:1.2.3.4 are treated as the equivalent IPv4 addresses throughout.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Addr[A netip.Addr | netip.Prefix | net.IP](value A, other ...any) AddrType {
+	a := AddrType{assertion: assertion{otherActual: other}}
+
+	switch v := any(value).(type) {
+	case netip.Prefix:
+		a.isPrefix = true
+		a.prefix = unmapPrefix(v)
+		a.addr = a.prefix.Addr()
+		if !v.IsValid() {
+			a.fault = "the prefix is not valid"
+		}
+	default:
+		addr, err := toAddr(v)
+		if err != nil {
+			a.fault = err.Error()
+		}
+		a.addr = addr
+	}
+
+	return a
+}
+
+// toAddr converts a [netip.Addr], a [net.IP] or a string to an unmapped address.
+func toAddr(value any) (netip.Addr, error) {
+	var addr netip.Addr
+	switch v := value.(type) {
+	case netip.Addr:
+		addr = v
+	case net.IP:
+		addr, _ = netip.AddrFromSlice(v)
+	case string:
+		var err error
+		addr, err = netip.ParseAddr(v)
+		if err != nil {
+			return addr, err
+		}
+	default:
+		return addr, fmt.Errorf("%T is not netip.Addr, net.IP or string", value)
+	}
+
+	if !addr.IsValid() {
+		return addr, fmt.Errorf("the address is not valid")
+	}
+	return addr.Unmap(), nil
+}
+
+// toPrefix converts a [netip.Prefix] or a string to an unmapped prefix.
+func toPrefix(value any) (netip.Prefix, error) {
+	var prefix netip.Prefix
+	switch v := value.(type) {
+	case netip.Prefix:
+		prefix = v
+	case string:
+		var err error
+		prefix, err = netip.ParsePrefix(v)
+		if err != nil {
+			return prefix, err
+		}
+	default:
+		return prefix, fmt.Errorf("%T is not netip.Prefix or string", value)
+	}
+
+	if !prefix.IsValid() {
+		return prefix, fmt.Errorf("the prefix is not valid")
+	}
+	return unmapPrefix(prefix), nil
+}
+
+func unmapPrefix(p netip.Prefix) netip.Prefix {
+	if p.Addr().Is4In6() && p.Bits() >= 96 {
+		return netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96)
+	}
+	return p
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a AddrType) Info(info any, other ...any) AddrType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a AddrType) I(info any, other ...any) AddrType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a AddrType) Not() AddrType {
+	a.not = !a.not
+	return a
+}
+
+func (a AddrType) describe() string {
+	if a.isPrefix {
+		return "prefix " + a.prefix.String()
+	}
+	return "address " + a.addr.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBe asserts that the address or prefix is the same as the expected one, which is a [netip.Addr],
+// [netip.Prefix], [net.IP] or a string. IPv4-mapped IPv6 addresses are equivalent to IPv4 addresses.
+// Prefixes are compared after masking, so 10.1.2.3/8 is the same as 10.0.0.0/8.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBe(t Tester, expected any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	var match bool
+	var shown string
+	if a.isPrefix {
+		ex, err := toPrefix(expected)
+		if err != nil && a.fault == "" {
+			a.fault = "the expected prefix is invalid: " + err.Error()
+		}
+		match = a.prefix.Masked() == ex.Masked()
+		shown = ex.String()
+	} else {
+		ex, err := toAddr(expected)
+		if err != nil && a.fault == "" {
+			a.fault = "the expected address is invalid: " + err.Error()
+		}
+		match = a.addr == ex
+		shown = ex.String()
+	}
+
+	a.toCompare(t, match, "to be", shown)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeLessThan asserts that the address is ordered before the threshold, which is a [netip.Addr],
+// [net.IP] or a string. All IPv4 addresses are ordered before all IPv6 addresses.
+// For prefixes, their addresses are compared.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeLessThan(t Tester, threshold any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	ex := a.threshold(threshold)
+	a.toCompare(t, a.addr.Less(ex), "to be less than", ex.String())
+}
+
+// ToBeGreaterThan asserts that the address is ordered after the threshold, which is a [netip.Addr],
+// [net.IP] or a string. All IPv6 addresses are ordered after all IPv4 addresses.
+// For prefixes, their addresses are compared.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeGreaterThan(t Tester, threshold any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	ex := a.threshold(threshold)
+	a.toCompare(t, ex.Less(a.addr), "to be greater than", ex.String())
+}
+
+func (a *AddrType) threshold(threshold any) netip.Addr {
+	ex, err := toAddr(threshold)
+	if err != nil && a.fault == "" {
+		a.fault = "the threshold is invalid: " + err.Error()
+	}
+	return ex
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeInPrefix asserts that the address is within the prefix, which is a [netip.Prefix] or a
+// string such as "10.0.0.0/8". For a prefix, it must be entirely within the expected prefix.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeInPrefix(t Tester, prefix any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	ex, err := toPrefix(prefix)
+	if err != nil && a.fault == "" {
+		a.fault = "the expected prefix is invalid: " + err.Error()
+	}
+
+	match := ex.Contains(a.addr)
+	if a.isPrefix {
+		match = match && ex.Bits() <= a.prefix.Bits()
+	}
+
+	a.toCompare(t, match, "to be in prefix", ex.String())
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeIPv4 asserts that the address is an IPv4 address, including IPv4-mapped IPv6 addresses.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeIPv4(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toBe(t, a.addr.Is4(), "IPv4")
+}
+
+// ToBeIPv6 asserts that the address is an IPv6 address, excluding IPv4-mapped IPv6 addresses.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeIPv6(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toBe(t, a.addr.Is6(), "IPv6")
+}
+
+// ToBeLoopback asserts that the address is a loopback address, such as 127.0.0.1 or ::1.
+// The tester is normally [*testing.T].
+func (a AddrType) ToBeLoopback(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toBe(t, a.addr.IsLoopback(), "a loopback address")
+}
+
+// ToBePrivate asserts that the address is a private address, as defined by RFC 1918 (IPv4)
+// and RFC 4193 (IPv6).
+// The tester is normally [*testing.T].
+func (a AddrType) ToBePrivate(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toBe(t, a.addr.IsPrivate(), "a private address")
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a AddrType) toBe(t Tester, match bool, what string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpected1("%s %sto be %s.\n", a.describe(), notS(a.not), what)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func (a AddrType) toCompare(t Tester, match bool, what, expected string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpected1("%s %s%s %s.\n", a.describe(), notS(a.not), what, expected)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
@@ -0,0 +1,120 @@
+package expect_test
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func TestAddrToBe(t *testing.T) {
+	c := &capture{}
+
+	expect.Addr(netip.MustParseAddr("::ffff:1.2.3.4")).ToBe(c, "1.2.3.4")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.ParseIP("1.2.3.4")).ToBe(c, netip.MustParseAddr("1.2.3.4"))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.IPv4(10, 0, 0, 1)).ToBe(c, net.ParseIP("::ffff:10.0.0.1"))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParsePrefix("::ffff:10.0.0.0/104")).ToBe(c, "10.0.0.0/8")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParsePrefix("10.1.2.3/8")).ToBe(c, "10.0.0.0/8")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParsePrefix("10.0.0.0/8")).ToBe(c, "10.0.0.0/16")
+	c.shouldHaveCalledErrorf(t, "Expected prefix 10.0.0.0/8 to be 10.0.0.0/16.\n")
+
+	expect.Addr(netip.MustParseAddr("1.2.3.4")).I("gateway").ToBe(c, "1.2.3.5")
+	c.shouldHaveCalledErrorf(t, "Expected gateway address 1.2.3.4 to be 1.2.3.5.\n")
+
+	expect.Addr(netip.MustParseAddr("1.2.3.4")).Not().ToBe(c, "::ffff:1.2.3.4")
+	c.shouldHaveCalledErrorf(t, "Expected address 1.2.3.4 not to be 1.2.3.4.\n")
+
+	expect.Addr(netip.MustParseAddr("1.2.3.4")).Not().ToBe(c, "1.2.3")
+	c.shouldHaveCalledFatalf(t, `Expected to be usable but the expected address is invalid: ParseAddr("1.2.3"): IPv4 address too short.`+"\n")
+
+	expect.Addr(net.IP{1, 2, 3}).Not().ToBe(c, "1.2.3.4")
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the address is not valid.\n")
+}
+
+func TestAddrProperties(t *testing.T) {
+	c := &capture{}
+
+	expect.Addr(net.ParseIP("::ffff:192.168.1.1")).ToBeIPv4(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.ParseIP("::ffff:192.168.1.1")).Not().ToBeIPv6(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.ParseIP("::ffff:192.168.1.1")).ToBePrivate(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.IPv6Loopback()).ToBeLoopback(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParseAddr("2001:db8::1")).ToBeIPv4(c)
+	c.shouldHaveCalledErrorf(t, "Expected address 2001:db8::1 to be IPv4.\n")
+
+	expect.Addr(netip.MustParseAddr("8.8.8.8")).ToBePrivate(c)
+	c.shouldHaveCalledErrorf(t, "Expected address 8.8.8.8 to be a private address.\n")
+
+	expect.Addr(netip.MustParseAddr("127.0.0.1")).Not().ToBeLoopback(c)
+	c.shouldHaveCalledErrorf(t, "Expected address 127.0.0.1 not to be a loopback address.\n")
+
+	expect.Addr(netip.MustParsePrefix("fd00::/8")).ToBeIPv4(c)
+	c.shouldHaveCalledErrorf(t, "Expected prefix fd00::/8 to be IPv4.\n")
+}
+
+func TestAddrToBeInPrefix(t *testing.T) {
+	c := &capture{}
+
+	expect.Addr(netip.MustParseAddr("10.1.2.3")).ToBeInPrefix(c, "10.0.0.0/8")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.ParseIP("::ffff:10.1.2.3")).ToBeInPrefix(c, netip.MustParsePrefix("10.1.0.0/16"))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParsePrefix("10.1.0.0/16")).ToBeInPrefix(c, "10.0.0.0/8")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParsePrefix("10.0.0.0/8")).ToBeInPrefix(c, "10.1.0.0/16")
+	c.shouldHaveCalledErrorf(t, "Expected prefix 10.0.0.0/8 to be in prefix 10.1.0.0/16.\n")
+
+	expect.Addr(netip.MustParseAddr("192.168.0.1")).I("allocated").ToBeInPrefix(c, "10.0.0.0/8")
+	c.shouldHaveCalledErrorf(t, "Expected allocated address 192.168.0.1 to be in prefix 10.0.0.0/8.\n")
+
+	expect.Addr(netip.MustParseAddr("10.1.2.3")).Not().ToBeInPrefix(c, "10.0.0.0/8")
+	c.shouldHaveCalledErrorf(t, "Expected address 10.1.2.3 not to be in prefix 10.0.0.0/8.\n")
+}
+
+func TestAddrOrdering(t *testing.T) {
+	c := &capture{}
+
+	expect.Addr(netip.MustParseAddr("10.0.0.9")).ToBeLessThan(c, "10.0.0.10")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(netip.MustParseAddr("::1")).ToBeGreaterThan(c, "255.255.255.255")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Addr(net.ParseIP("::ffff:10.0.0.10")).ToBeLessThan(c, "10.0.0.9")
+	c.shouldHaveCalledErrorf(t, "Expected address 10.0.0.10 to be less than 10.0.0.9.\n")
+
+	expect.Addr(netip.MustParseAddr("10.0.0.10")).Not().ToBeGreaterThan(c, net.IPv4(10, 0, 0, 9))
+	c.shouldHaveCalledErrorf(t, "Expected address 10.0.0.10 not to be greater than 10.0.0.9.\n")
+}
+
+func ExampleAddr() {
+	var t *testing.T
+
+	allocated := netip.MustParseAddr("10.1.2.3") // e.g. from the code under test
+
+	expect.Addr(allocated).ToBeIPv4(t)
+	expect.Addr(allocated).ToBePrivate(t)
+	expect.Addr(allocated).ToBeInPrefix(t, "10.1.0.0/16")
+	expect.Addr(allocated).ToBeGreaterThan(t, "10.1.0.0")
+}
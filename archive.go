@@ -0,0 +1,341 @@
+package expect
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// ArchiveType is used for assertions about the entries in zip, tar and tar.gz archives.
+type ArchiveType struct {
+	format  string
+	entries []archiveEntry
+	assertion
+}
+
+type archiveEntry struct {
+	name string
+	mode fs.FileMode
+	data []byte
+}
+
+// Archive creates an assertion about a zip, tar or gzip-compressed tar archive held in a
+// []byte, a string or an [io.Reader]. The format is detected from the data; empty data is
+// not recognised as any format.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Archive(data any, other ...any) ArchiveType {
+	a := ArchiveType{assertion: assertion{otherActual: other}}
+
+	rdr, err := readerOf(data)
+	if err != nil {
+		a.fault = err.Error()
+		return a
+	}
+
+	b, err := io.ReadAll(rdr)
+	if err != nil {
+		a.fault = fmt.Sprintf("the archive could not be read: %v", err)
+		return a
+	}
+
+	switch {
+	case len(b) == 0:
+		a.fault = "the archive format is unrecognised because there is no data"
+		return a
+	case bytes.HasPrefix(b, []byte("PK\x03\x04")) || bytes.HasPrefix(b, []byte("PK\x05\x06")):
+		a.format = "zip"
+		a.entries, err = readZip(b)
+	case bytes.HasPrefix(b, []byte{0x1f, 0x8b}):
+		a.format = "tar.gz"
+		a.entries, err = readTarGz(b)
+	default:
+		a.format = "tar"
+		a.entries, err = readTar(bytes.NewReader(b))
+	}
+
+	if err != nil {
+		a.fault = fmt.Sprintf("the %s archive could not be read: %v", a.format, err)
+	}
+	return a
+}
+
+func readZip(b []byte) ([]archiveEntry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]archiveEntry, 0, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{name: f.Name, mode: f.Mode(), data: data})
+	}
+	return entries, nil
+}
+
+func readTarGz(b []byte) ([]archiveEntry, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return readTar(gz)
+}
+
+func readTar(r io.Reader) ([]archiveEntry, error) {
+	tr := tar.NewReader(r)
+
+	var entries []archiveEntry
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, archiveEntry{name: hdr.Name, mode: hdr.FileInfo().Mode(), data: data})
+	}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a ArchiveType) Info(info any, other ...any) ArchiveType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a ArchiveType) I(info any, other ...any) ArchiveType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a ArchiveType) Not() ArchiveType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveCount asserts that the archive has the expected number of entries, including any
+// directory entries.
+// The tester is normally [*testing.T].
+func (a ArchiveType) ToHaveCount(t Tester, expected int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && len(a.entries) != expected) || (a.not && len(a.entries) == expected) {
+		a.describeEntries()
+		a.addExpectation("to have %s.\n", nEntries.FormatInt(expected))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToContain asserts that the archive contains entries with all of the names listed.
+// The tester is normally [*testing.T].
+func (a ArchiveType) ToContain(t Tester, names ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	var missing []string
+	for _, name := range names {
+		if _, found := a.entry(name); !found {
+			missing = append(missing, name)
+		}
+	}
+
+	if !a.not && len(missing) > 0 {
+		a.describeEntries()
+		a.addExpectation("to contain %s but %s missing ―――\n%s\n",
+			allN.FormatInt(len(names)), theseWere.FormatInt(len(missing)), strings.Join(missing, "\n"))
+	} else if a.not && len(missing) == 0 {
+		a.describeEntries()
+		a.addExpectation("to contain %s but %s present ―――\n%s\n",
+			allN.FormatInt(len(names)), theyWereAll.FormatInt(len(names)), strings.Join(names, "\n"))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveEntries asserts that the archive contains exactly the entries listed, in any order.
+// The tester is normally [*testing.T].
+func (a ArchiveType) ToHaveEntries(t Tester, names ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual := make([]string, len(a.entries))
+	for i, e := range a.entries {
+		actual[i] = e.name
+	}
+	slices.Sort(actual)
+
+	expected := slices.Sorted(slices.Values(names))
+	match := slices.Equal(actual, expected)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeEntries()
+		a.addExpectation("to have %s ―――\n%s\n", nEntries.FormatInt(len(names)), strings.Join(expected, "\n"))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Entry selects an entry by name so that assertions can be made about its mode and contents.
+func (a ArchiveType) Entry(name string) ArchiveEntryType {
+	e := ArchiveEntryType{name: name, assertion: assertion{otherActual: a.otherActual, fault: a.fault}}
+	e.info = entryInfo(a.info, name)
+
+	if e.fault == "" {
+		var found bool
+		e.actual, found = a.entry(name)
+		if !found {
+			e.fault = fmt.Sprintf("the %s archive has no such entry", a.format)
+		}
+	}
+	return e
+}
+
+func entryInfo(info, name string) string {
+	if info == "" {
+		return "entry " + name
+	}
+	return info + " entry " + name
+}
+
+func (a ArchiveType) entry(name string) (archiveEntry, bool) {
+	for _, e := range a.entries {
+		if e.name == name {
+			return e, true
+		}
+	}
+	return archiveEntry{}, false
+}
+
+// describeEntries lists the entries with their sizes and modes.
+func (a *ArchiveType) describeEntries() {
+	width := 1
+	for _, e := range a.entries {
+		width = max(width, len(fmt.Sprint(len(e.data))))
+	}
+
+	var buf strings.Builder
+	for _, e := range a.entries {
+		fmt.Fprintf(&buf, "%*d %s %s\n", width, len(e.data), e.mode, e.name)
+	}
+
+	a.describeActualExpectedM("%s archive with %s ―――\n%s", a.format, nEntries.FormatInt(len(a.entries)), buf.String())
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ArchiveEntryType is used for assertions about an entry in an archive.
+type ArchiveEntryType struct {
+	actual archiveEntry
+	name   string
+	assertion
+}
+
+// Info adds a description of the assertion to be included in any error message, in place of
+// any description given to the [ArchiveType]. The entry name is also included.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a ArchiveEntryType) Info(info any, other ...any) ArchiveEntryType {
+	a.info = entryInfo(makeInfo(info, other...), a.name)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a ArchiveEntryType) I(info any, other ...any) ArchiveEntryType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a ArchiveEntryType) Not() ArchiveEntryType {
+	a.not = !a.not
+	return a
+}
+
+// ToHaveMode asserts that the entry has the expected mode. Only the permission bits are
+// compared unless the expected mode also includes type bits such as [fs.ModeDir].
+// The tester is normally [*testing.T].
+func (a ArchiveEntryType) ToHaveMode(t Tester, mode fs.FileMode) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual := a.actual.mode
+	if mode.Type() == 0 {
+		actual = actual.Perm()
+	}
+
+	if (!a.not && actual != mode) || (a.not && actual == mode) {
+		a.describeActualExpected1("%sto have mode %s but it was %s.\n", notS(a.not), mode, a.actual.mode)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+// String passes the contents of the entry to a [String] assertion.
+func (a ArchiveEntryType) String() *StringType[string] {
+	s := String(string(a.actual.data), a.otherActual...).Info(a.info)
+	s.fault = a.fault
+	return s
+}
+
+// Bytes passes the contents of the entry to a [Slice] assertion.
+func (a ArchiveEntryType) Bytes() SliceType[byte] {
+	s := Slice(a.actual.data, a.otherActual...).Info(a.info)
+	s.fault = a.fault
+	return s
+}
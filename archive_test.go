@@ -0,0 +1,182 @@
+package expect_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+type testEntry struct {
+	name string
+	mode fs.FileMode
+	data string
+}
+
+var releaseEntries = []testEntry{
+	{"bin/", fs.ModeDir | 0o755, ""},
+	{"bin/app", 0o755, "#!/bin/sh\necho app\n"},
+	{"README.md", 0o644, "# app\n"},
+}
+
+func makeZip(t *testing.T, entries []testEntry) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, e := range entries {
+		fh := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		fh.SetMode(e.mode)
+		w, err := zw.CreateHeader(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(e.data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func makeTar(t *testing.T, entries []testEntry, compress bool) []byte {
+	buf := &bytes.Buffer{}
+	var gz *gzip.Writer
+	tw := tar.NewWriter(buf)
+	if compress {
+		gz = gzip.NewWriter(buf)
+		tw = tar.NewWriter(gz)
+	}
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: int64(e.mode.Perm()), Size: int64(len(e.data)), Typeflag: tar.TypeReg}
+		if e.mode.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = tw.Write([]byte(e.data))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		_ = gz.Close()
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveFormats(t *testing.T) {
+	c := &capture{}
+
+	for _, data := range [][]byte{makeZip(t, releaseEntries), makeTar(t, releaseEntries, false), makeTar(t, releaseEntries, true)} {
+		expect.Archive(data).ToHaveCount(c, 3)
+		c.shouldNotHaveHadAnError(t)
+
+		expect.Archive(bytes.NewReader(data)).ToHaveEntries(c, "README.md", "bin/", "bin/app")
+		c.shouldNotHaveHadAnError(t)
+
+		expect.Archive(data).Entry("bin/app").ToHaveMode(c, 0o755)
+		c.shouldNotHaveHadAnError(t)
+
+		expect.Archive(data).Entry("bin/").ToHaveMode(c, fs.ModeDir|0o755)
+		c.shouldNotHaveHadAnError(t)
+
+		expect.Archive(data).Entry("README.md").String().ToBe(c, "# app\n")
+		c.shouldNotHaveHadAnError(t)
+
+		expect.Archive(data).Entry("README.md").Bytes().ToHaveLength(c, 6)
+		c.shouldNotHaveHadAnError(t)
+	}
+}
+
+func TestArchiveToContain(t *testing.T) {
+	c := &capture{}
+	data := makeZip(t, releaseEntries)
+
+	expect.Archive(data).ToContain(c, "bin/app", "README.md")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Archive(data).Not().ToContain(c, "LICENSE")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Archive(data).I("release").ToContain(c, "bin/app", "LICENSE")
+	c.shouldHaveCalledErrorf(t, `Expected release zip archive with 3 entries ―――
+ 0 drwxr-xr-x bin/
+19 -rwxr-xr-x bin/app
+ 6 -rw-r--r-- README.md
+――― to contain both but this was missing ―――
+LICENSE
+`)
+
+	expect.Archive(makeTar(t, releaseEntries, true)).Not().ToContain(c, "bin/app")
+	c.shouldHaveCalledErrorf(t, `Expected tar.gz archive with 3 entries ―――
+ 0 drwxr-xr-x bin/
+19 -rwxr-xr-x bin/app
+ 6 -rw-r--r-- README.md
+――― not to contain it but it was present ―――
+bin/app
+`)
+}
+
+func TestArchiveToHaveCountAndEntries(t *testing.T) {
+	c := &capture{}
+	data := makeTar(t, releaseEntries[1:], false)
+
+	expect.Archive(data).ToHaveCount(c, 1)
+	c.shouldHaveCalledErrorf(t, `Expected tar archive with 2 entries ―――
+19 -rwxr-xr-x bin/app
+ 6 -rw-r--r-- README.md
+――― to have one entry.
+`)
+
+	expect.Archive(data).ToHaveEntries(c, "bin/app", "LICENSE")
+	c.shouldHaveCalledErrorf(t, `Expected tar archive with 2 entries ―――
+19 -rwxr-xr-x bin/app
+ 6 -rw-r--r-- README.md
+――― to have 2 entries ―――
+LICENSE
+bin/app
+`)
+}
+
+func TestArchiveEntry(t *testing.T) {
+	c := &capture{}
+	data := makeZip(t, releaseEntries)
+
+	expect.Archive(data).Entry("README.md").ToHaveMode(c, 0o600)
+	c.shouldHaveCalledErrorf(t, "Expected entry README.md to have mode -rw------- but it was -rw-r--r--.\n")
+
+	expect.Archive(data).I("release").Entry("bin/app").String().ToContain(c, "version")
+	c.shouldHaveCalledErrorf(t, `Expected release entry bin/app string len:19 ―――
+#!/bin/sh␤
+echo app␤
+
+――― to contain ―――
+version
+`)
+
+	expect.Archive(data).Entry("LICENSE").Not().ToHaveMode(c, 0o600)
+	c.shouldHaveCalledFatalf(t, "Expected entry LICENSE to be usable but the zip archive has no such entry.\n")
+
+	expect.Archive(data).I("release").Entry("README.md").I("notes").ToHaveMode(c, 0o600)
+	c.shouldHaveCalledErrorf(t, "Expected notes entry README.md to have mode -rw------- but it was -rw-r--r--.\n")
+
+	expect.Archive([]byte{}).Not().ToHaveCount(c, 1)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the archive format is unrecognised because there is no data.\n")
+
+	expect.Archive([]byte("PK\x03\x04 junk")).Not().ToHaveCount(c, 1)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the zip archive could not be read: zip: not a valid zip file.\n")
+}
+
+func ExampleArchive() {
+	var t *testing.T
+
+	var release []byte // ... a .zip, .tar or .tar.gz built by the code under test
+
+	expect.Archive(release).ToContain(t, "bin/app", "README.md")
+	expect.Archive(release).Entry("bin/app").ToHaveMode(t, 0o755)
+	expect.Archive(release).Entry("README.md").String().ToContain(t, "Usage")
+}
@@ -0,0 +1,133 @@
+package expect
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// BenchmarkType is used for assertions about performance, measured using [testing.Benchmark].
+type BenchmarkType struct {
+	actual func(b *testing.B)
+	assertion
+}
+
+// Benchmark wraps a benchmark function so that assertions can be made about its speed.
+// The benchmark is run by each assertion using [testing.Benchmark], so it obeys the
+// -test.benchtime flag.
+//
+// Benchmark assertions do nothing when tests are run with the -short flag.
+func Benchmark(value func(b *testing.B)) BenchmarkType {
+	return BenchmarkType{actual: value}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a BenchmarkType) Info(info any, other ...any) BenchmarkType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a BenchmarkType) I(info any, other ...any) BenchmarkType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a BenchmarkType) Not() BenchmarkType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToRunFasterThan asserts that the benchmark takes less than a specified duration per operation.
+// The tester is normally [*testing.T].
+func (a BenchmarkType) ToRunFasterThan(t Tester, d time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if skipBenchmark(t) {
+		return
+	}
+
+	result := testing.Benchmark(a.actual)
+	if result.N == 0 {
+		a.describeNotRun(false)
+		a.applyAll(t)
+		return
+	}
+
+	actual := time.Duration(result.NsPerOp())
+	faster := actual < d
+
+	if (!a.not && !faster) || (a.not && faster) {
+		a.describeActualExpected1("%sto run faster than %s per op but got ―――\n%s\n",
+			notS(a.not), d, benchmarkSummary(result))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeFasterThan asserts that the benchmark is faster than some other benchmark by at least
+// a given factor. The ratio is the other's time per operation divided by this one's, so a
+// factor of 2 requires this benchmark to take no more than half as long as the other.
+// The tester is normally [*testing.T].
+func (a BenchmarkType) ToBeFasterThan(t Tester, other func(b *testing.B), factor float64) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if skipBenchmark(t) {
+		return
+	}
+
+	result := testing.Benchmark(a.actual)
+	otherResult := testing.Benchmark(other)
+	if result.N == 0 || otherResult.N == 0 {
+		a.describeNotRun(result.N != 0)
+		a.applyAll(t)
+		return
+	}
+
+	ratio := float64(otherResult.NsPerOp()) / float64(max(result.NsPerOp(), 1))
+	faster := ratio >= factor
+
+	if (!a.not && !faster) || (a.not && faster) {
+		a.describeActualExpected1("%sto be faster than the other by a factor of %g but got ―――\n"+
+			" this: %s\nother: %s\nratio: %.2f\n",
+			notS(a.not), factor, benchmarkSummary(result), benchmarkSummary(otherResult), ratio)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//=================================================================================================
+
+func skipBenchmark(t Tester) bool {
+	return testing.Testing() && testing.Short()
+}
+
+// describeNotRun reports a benchmark that stopped before running, e.g. because it called
+// b.Fatal or b.Skip. This is a failure even with Not().
+func (a *assertion) describeNotRun(other bool) {
+	if other {
+		a.describeActual("Expected%s benchmark to be compared with the other but the other did not run; "+
+			"it may have called b.Fatal or b.Skip.\n", preS(a.info))
+	} else {
+		a.describeActual("Expected%s benchmark to run but it did not; it may have called b.Fatal or b.Skip.\n", preS(a.info))
+	}
+}
+
+func benchmarkSummary(r testing.BenchmarkResult) string {
+	return fmt.Sprintf("%d ns/op, %d allocs/op", r.NsPerOp(), r.AllocsPerOp())
+}
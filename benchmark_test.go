@@ -0,0 +1,132 @@
+package expect_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func fastBenchmark(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sink = make([]byte, 8)
+	}
+}
+
+func slowBenchmark(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBenchmarkToRunFasterThan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmarks are not run in short mode")
+	}
+	setBenchTime(t, "20x")
+
+	c := &capture{}
+
+	expect.Benchmark(fastBenchmark).I("fast").ToRunFasterThan(c, time.Millisecond)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Benchmark(slowBenchmark).I("slow").Not().ToRunFasterThan(c, time.Millisecond)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Benchmark(slowBenchmark).I("slow").ToRunFasterThan(c, time.Millisecond)
+	c.shouldHaveCalledErrorfRE(t, `^Expected slow to run faster than 1ms per op but got ―――\n\d+ ns/op, 0 allocs/op\n$`)
+
+	expect.Benchmark(fastBenchmark).I("fast").Not().ToRunFasterThan(c, time.Millisecond)
+	c.shouldHaveCalledErrorfRE(t, `^Expected fast not to run faster than 1ms per op but got ―――\n\d+ ns/op, 1 allocs/op\n$`)
+}
+
+func TestBenchmarkToBeFasterThan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmarks are not run in short mode")
+	}
+	setBenchTime(t, "20x")
+
+	c := &capture{}
+
+	expect.Benchmark(fastBenchmark).I("fast").ToBeFasterThan(c, slowBenchmark, 10)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Benchmark(slowBenchmark).I("slow").ToBeFasterThan(c, fastBenchmark, 1)
+	c.shouldHaveCalledErrorfRE(t, `^Expected slow to be faster than the other by a factor of 1 but got ―――\n`+
+		` this: \d+ ns/op, 0 allocs/op\n`+
+		`other: \d+ ns/op, 1 allocs/op\n`+
+		`ratio: 0\.\d\d\n$`)
+}
+
+func brokenBenchmark(b *testing.B) {
+	b.Fatal("broken")
+}
+
+func TestBenchmarkNotRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("benchmarks are not run in short mode")
+	}
+	setBenchTime(t, "20x")
+
+	c := &capture{}
+
+	expect.Benchmark(brokenBenchmark).I("broken").ToRunFasterThan(c, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected broken benchmark to run but it did not; it may have called b.Fatal or b.Skip.\n")
+
+	expect.Benchmark(brokenBenchmark).Not().ToRunFasterThan(c, time.Nanosecond)
+	c.shouldHaveCalledErrorf(t, "Expected benchmark to run but it did not; it may have called b.Fatal or b.Skip.\n")
+
+	expect.Benchmark(brokenBenchmark).ToBeFasterThan(c, fastBenchmark, 1)
+	c.shouldHaveCalledErrorf(t, "Expected benchmark to run but it did not; it may have called b.Fatal or b.Skip.\n")
+
+	expect.Benchmark(fastBenchmark).I("fast").ToBeFasterThan(c, func(b *testing.B) { b.Skip("not today") }, 1)
+	c.shouldHaveCalledErrorf(t, "Expected fast benchmark to be compared with the other but the other did not run; "+
+		"it may have called b.Fatal or b.Skip.\n")
+}
+
+func TestBenchmarkShort(t *testing.T) {
+	setFlag(t, "test.short", "true")
+
+	reached := false
+	t.Run("short", func(t *testing.T) {
+		expect.Benchmark(slowBenchmark).ToRunFasterThan(t, time.Nanosecond)
+		expect.Benchmark(slowBenchmark).ToBeFasterThan(t, fastBenchmark, 10)
+		reached = true
+	})
+
+	expect.Bool(reached).I("code after benchmark assertions in short mode was run").ToBeTrue(t)
+}
+
+func setBenchTime(t *testing.T, value string) {
+	setFlag(t, "test.benchtime", value)
+}
+
+func setFlag(t *testing.T, name, value string) {
+	f := flag.Lookup(name)
+	previous := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = f.Value.Set(previous) })
+}
+
+func ExampleBenchmark() {
+	var t *testing.T
+
+	fast := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = make([]byte, 10)
+		}
+	}
+
+	slow := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	expect.Benchmark(fast).ToRunFasterThan(t, time.Millisecond)
+
+	expect.Benchmark(fast).ToBeFasterThan(t, slow, 10)
+}
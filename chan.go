@@ -0,0 +1,229 @@
+package expect
+
+import (
+	"time"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	"github.com/rickb777/plural"
+)
+
+// ChanTimeout is the default time that [ChanType] assertions will wait for a channel
+// to be ready. It can be changed for a particular assertion using [ChanType.Within].
+var ChanTimeout = time.Second
+
+// ChanType is used for assertions about channels.
+type ChanType[T any] struct {
+	opts    gocmp.Options
+	actual  <-chan T
+	timeout time.Duration
+	assertion
+}
+
+// Chan creates an assertion about values received from a channel. Note that the assertions
+// receive values from the channel, so they are no longer available to other receivers.
+//
+// This uses [gocmp.Equal] so the manner of comparison can be tweaked using that API - see also [ChanType.Using]
+func Chan[T any](value <-chan T) ChanType[T] {
+	return ChanType[T]{actual: value, opts: DefaultOptions(), timeout: ChanTimeout}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a ChanType[T]) Info(info any, other ...any) ChanType[T] {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a ChanType[T]) I(info any, other ...any) ChanType[T] {
+	return a.Info(info, other...)
+}
+
+// Using replaces the default comparison options with those specified here.
+// You can also set [DefaultOptions] instead.
+func (a ChanType[T]) Using(opt ...gocmp.Option) ChanType[T] {
+	a.opts = opt
+	return a
+}
+
+// Within sets the time that [ChanType.ToBeClosed] and [ChanType.ToReceiveInOrder] will
+// wait for the channel. The default is [ChanTimeout].
+func (a ChanType[T]) Within(timeout time.Duration) ChanType[T] {
+	a.timeout = timeout
+	return a
+}
+
+// Not inverts the assertion.
+func (a ChanType[T]) Not() ChanType[T] {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+var nValues = plural.FromOne("one value", "%d values")
+
+type chanOutcome int
+
+const (
+	chanReceived chanOutcome = iota
+	chanClosed
+	chanTimedOut
+)
+
+func (a ChanType[T]) receive(within time.Duration) (T, chanOutcome) {
+	timer := time.NewTimer(within)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-a.actual:
+		if !ok {
+			return v, chanClosed
+		}
+		return v, chanReceived
+	case <-timer.C:
+		var zero T
+		return zero, chanTimedOut
+	}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToReceive asserts that the channel delivers a value within the specified time. Using [ChanType.Not],
+// it asserts that no value is received during that time.
+//
+// The received value is returned as an [AnyType] so that further assertions can be made about it.
+// If nothing was received, assertions about it are skipped.
+// The tester is normally [*testing.T].
+func (a ChanType[T]) ToReceive(t Tester, within time.Duration) AnyType[T] {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	v, outcome := a.receive(within)
+
+	switch {
+	case !a.not && outcome == chanTimedOut:
+		a.describeActualExpected1("%T to receive a value within %s but nothing was received.\n", a.actual, within)
+	case !a.not && outcome == chanClosed:
+		a.describeActualExpected1("%T to receive a value within %s but it was closed.\n", a.actual, within)
+	case a.not && outcome == chanReceived:
+		a.describeActualExpected1("%T not to receive a value within %s but received ―――\n%s", a.actual, within, verbatim2(v))
+	default:
+		a.passes++
+	}
+
+	a.applyAll(t)
+	received := Value(v).Info(a.info).Using(a.opts...)
+	received.inert = outcome != chanReceived
+	return received
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToReceiveValue asserts that the channel delivers the expected value within the specified time.
+// The tester is normally [*testing.T].
+func (a ChanType[T]) ToReceiveValue(t Tester, expected T, within time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	v, outcome := a.receive(within)
+
+	opts := append(a.opts, allowUnexported(gatherTypes(nil, v, expected)))
+	match := outcome == chanReceived && gocmp.Equal(expected, v, opts)
+
+	if !a.not && !match {
+		a.describeActualExpectedM("%T to receive ―――\n%s", a.actual, verbatim2(expected))
+		switch outcome {
+		case chanTimedOut:
+			a.addExpectation("within %s but nothing was received.\n", within)
+		case chanClosed:
+			a.addExpectation("within %s but it was closed.\n", within)
+		default:
+			a.addExpectation("within %s but received ―――\n%s", within, verbatim2(v))
+		}
+	} else if a.not && match {
+		a.describeActualExpected1("%T not to receive ―――\n%s――― within %s.\n", a.actual, verbatim2(expected), within)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeClosed asserts that the channel is closed, waiting up to the time set by [ChanType.Within]
+// for this to happen. It fails if a value is received instead.
+// The tester is normally [*testing.T].
+func (a ChanType[T]) ToBeClosed(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	v, outcome := a.receive(a.timeout)
+
+	switch {
+	case !a.not && outcome == chanTimedOut:
+		a.describeActualExpected1("%T to be closed but it was still open after %s.\n", a.actual, a.timeout)
+	case !a.not && outcome == chanReceived:
+		a.describeActualExpected1("%T to be closed but received ―――\n%s", a.actual, verbatim2(v))
+	case a.not && outcome == chanClosed:
+		a.describeActualExpected1("%T not to be closed.\n", a.actual)
+	default:
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToReceiveInOrder asserts that the channel delivers the expected values in order. It waits up to
+// the time set by [ChanType.Within] for each value. It is not useful to use [ChanType.Not] with this.
+// The tester is normally [*testing.T].
+func (a ChanType[T]) ToReceiveInOrder(t Tester, expected ...T) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if a.not {
+		panic("Chan().ToReceiveInOrder() does not allow Not() because of ambiguous meaning")
+	}
+
+	received := make([]T, 0, len(expected))
+
+	for i, ex := range expected {
+		v, outcome := a.receive(a.timeout)
+
+		switch outcome {
+		case chanTimedOut:
+			a.describeActualExpectedM("%T to receive %s in order ―――\n%s", a.actual, nValues.FormatInt(len(expected)), verbatim2(expected))
+			a.addExpectation("but nothing was received within %s after receiving ―――\n%s", a.timeout, verbatim2(received))
+			a.applyAll(t)
+			return
+
+		case chanClosed:
+			a.describeActualExpectedM("%T to receive %s in order ―――\n%s", a.actual, nValues.FormatInt(len(expected)), verbatim2(expected))
+			a.addExpectation("but it was closed after receiving ―――\n%s", verbatim2(received))
+			a.applyAll(t)
+			return
+		}
+
+		opts := append(a.opts, allowUnexported(gatherTypes(nil, v, ex)))
+		if !gocmp.Equal(ex, v, opts) {
+			a.describeActualExpectedM("%T to receive %s in order ―――\n%s", a.actual, nValues.FormatInt(len(expected)), verbatim2(expected))
+			a.addExpectation("but value %d was ―――\n%s――― after receiving ―――\n%s", i, verbatim2(v), verbatim2(received))
+			a.applyAll(t)
+			return
+		}
+
+		received = append(received, v)
+	}
+
+	a.passes++
+	a.applyAll(t)
+}
@@ -0,0 +1,117 @@
+package expect_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func bufferedChan(values ...int) chan int {
+	ch := make(chan int, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	return ch
+}
+
+func TestChanToReceive(t *testing.T) {
+	c := &capture{}
+
+	expect.Chan(bufferedChan(1)).ToReceive(c, time.Millisecond).ToBe(c, 1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan()).Not().ToReceive(c, time.Millisecond)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan()).I("results").ToReceive(c, time.Millisecond).Not().ToBe(c, 0)
+	c.shouldHaveCalledErrorf(t, "Expected results <-chan int to receive a value within 1ms but nothing was received.\n")
+
+	closed := bufferedChan()
+	close(closed)
+	expect.Chan(closed).ToReceive(c, time.Millisecond).Not().ToBe(c, 0)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive a value within 1ms but it was closed.\n")
+
+	expect.Chan(bufferedChan()).Not().ToReceive(c, time.Millisecond).ToBe(c, 1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan(42)).Not().ToReceive(c, time.Millisecond)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int not to receive a value within 1ms but received ―――\n42\n")
+}
+
+func TestChanToReceiveValue(t *testing.T) {
+	c := &capture{}
+
+	expect.Chan(bufferedChan(1)).ToReceiveValue(c, 1, time.Millisecond)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan(2)).Not().ToReceiveValue(c, 1, time.Millisecond)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan(2)).ToReceiveValue(c, 1, time.Millisecond)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive ―――\n1\n――― within 1ms but received ―――\n2\n")
+
+	expect.Chan(bufferedChan()).ToReceiveValue(c, 1, time.Millisecond)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive ―――\n1\n――― within 1ms but nothing was received.\n")
+
+	expect.Chan(bufferedChan(1)).Not().ToReceiveValue(c, 1, time.Millisecond)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int not to receive ―――\n1\n――― within 1ms.\n")
+}
+
+func TestChanToBeClosed(t *testing.T) {
+	c := &capture{}
+
+	ch := make(chan int)
+	go func() {
+		time.Sleep(time.Millisecond)
+		close(ch)
+	}()
+	expect.Chan(ch).ToBeClosed(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan()).Within(time.Millisecond).Not().ToBeClosed(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan()).Within(time.Millisecond).ToBeClosed(c)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to be closed but it was still open after 1ms.\n")
+
+	expect.Chan(bufferedChan(3)).Within(time.Millisecond).ToBeClosed(c)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to be closed but received ―――\n3\n")
+
+	closed := bufferedChan()
+	close(closed)
+	expect.Chan(closed).Not().ToBeClosed(c)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int not to be closed.\n")
+}
+
+func TestChanToReceiveInOrder(t *testing.T) {
+	c := &capture{}
+
+	expect.Chan(bufferedChan(1, 2, 3)).ToReceiveInOrder(c, 1, 2, 3)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Chan(bufferedChan(1, 3, 2)).ToReceiveInOrder(c, 1, 2, 3)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive 3 values in order ―――\n[1 2 3]\n"+
+		"――― but value 1 was ―――\n3\n――― after receiving ―――\n[1]\n")
+
+	expect.Chan(bufferedChan(1)).Within(time.Millisecond).ToReceiveInOrder(c, 1, 2)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive 2 values in order ―――\n[1 2]\n"+
+		"――― but nothing was received within 1ms after receiving ―――\n[1]\n")
+
+	closed := bufferedChan()
+	close(closed)
+	expect.Chan(closed).ToReceiveInOrder(c, 1)
+	c.shouldHaveCalledErrorf(t, "Expected <-chan int to receive one value in order ―――\n[1]\n"+
+		"――― but it was closed after receiving ―――\n[]\n")
+}
+
+func ExampleChanType_ToReceive() {
+	var t *testing.T
+
+	results := make(chan string, 1)
+	go func() { results <- "done" }()
+
+	expect.Chan(results).ToReceive(t, time.Second).ToBe(t, "done")
+
+	expect.Chan(results).Not().ToReceive(t, 10*time.Millisecond)
+}
@@ -0,0 +1,194 @@
+package expect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandType is used for assertions about external commands.
+type CommandType struct {
+	actual  *exec.Cmd
+	outcome *commandOutcome
+	timeout time.Duration
+	trim    int
+	assertion
+}
+
+type commandOutcome struct {
+	once           sync.Once
+	stdout, stderr bytes.Buffer
+	code           int
+	err            error
+	timedOut       bool
+}
+
+// Command creates an assertion about an external command. The command is run once, when the
+// first assertion is made; all assertions on it then share the same outcome. The standard output
+// and standard error of the command are captured (as well as being written to cmd.Stdout and
+// cmd.Stderr if these were set).
+func Command(cmd *exec.Cmd) CommandType {
+	return CommandType{actual: cmd, outcome: &commandOutcome{}}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a CommandType) Info(info any, other ...any) CommandType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a CommandType) I(info any, other ...any) CommandType {
+	return a.Info(info, other...)
+}
+
+// Trim shortens the output shown in error messages when it is very long.
+// See [StringType.Trim].
+func (a CommandType) Trim(at int) CommandType {
+	a.trim = at
+	return a
+}
+
+// Timeout sets the maximum time the command is allowed to run. If it takes longer, it is killed
+// and the assertion fails. This has no effect if the command has already been run.
+func (a CommandType) Timeout(d time.Duration) CommandType {
+	a.timeout = d
+	return a
+}
+
+// Not inverts the assertion.
+func (a CommandType) Not() CommandType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToSucceed asserts that the command ran and exited with status zero.
+// The tester is normally [*testing.T].
+func (a CommandType) ToSucceed(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toExitWith(t, 0, "to succeed")
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToExitWith asserts that the command ran and exited with the specified status.
+// The tester is normally [*testing.T].
+func (a CommandType) ToExitWith(t Tester, code int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toExitWith(t, code, fmt.Sprintf("to exit with status %d", code))
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a CommandType) toExitWith(t Tester, code int, what string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	o := a.run()
+
+	if o.timedOut {
+		a.describeActualExpected1("command ―――\n%s\n――― %s%s but it timed out after %s; %s",
+			a.actual, notS(a.not), what, a.timeout, a.output())
+	} else if o.err != nil {
+		a.describeActualExpected1("command ―――\n%s\n――― %s%s but it could not be run ―――\n%v\n",
+			a.actual, notS(a.not), what, o.err)
+	} else if (!a.not && o.code != code) || (a.not && o.code == code) {
+		a.describeActualExpected1("command ―――\n%s\n――― %s%s but it exited with status %d; %s",
+			a.actual, notS(a.not), what, o.code, a.output())
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Stdout runs the command if necessary and returns a string assertion for its standard output.
+func (a CommandType) Stdout() *StringType[string] {
+	return String(a.run().stdout.String()).Info(prefix("stdout of ", a.info)).Trim(a.trim)
+}
+
+// Stderr runs the command if necessary and returns a string assertion for its standard error.
+func (a CommandType) Stderr() *StringType[string] {
+	return String(a.run().stderr.String()).Info(prefix("stderr of ", a.info)).Trim(a.trim)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a CommandType) run() *commandOutcome {
+	o := a.outcome
+	o.once.Do(func() {
+		cmd := a.actual
+		cmd.Stdout = teeWriter(&o.stdout, cmd.Stdout)
+		cmd.Stderr = teeWriter(&o.stderr, cmd.Stderr)
+
+		if a.timeout > 0 && cmd.WaitDelay == 0 {
+			cmd.WaitDelay = time.Second // in case orphaned subprocesses hold the output open
+		}
+
+		if err := cmd.Start(); err != nil {
+			o.err = err
+			return
+		}
+
+		var timer <-chan time.Time
+		if a.timeout > 0 {
+			timer = time.After(a.timeout)
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-timer:
+			o.timedOut = true
+			_ = cmd.Process.Kill()
+			err = <-done
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			o.code = exitErr.ExitCode()
+		} else if err != nil {
+			o.err = err
+		}
+	})
+	return o
+}
+
+func teeWriter(capture *bytes.Buffer, w io.Writer) io.Writer {
+	if w == nil {
+		return capture
+	}
+	return io.MultiWriter(capture, w)
+}
+
+func (a CommandType) output() string {
+	return describeOutput(a.outcome.stdout.String(), a.outcome.stderr.String(), a.trim)
+}
+
+func describeOutput(stdout, stderr string, trimAt int) string {
+	return fmt.Sprintf("stdout ―――\n%s\n――― stderr ―――\n%s\n",
+		trim(strings.TrimSpace(stdout), trimAt),
+		trim(strings.TrimSpace(stderr), trimAt))
+}
@@ -0,0 +1,101 @@
+package expect_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+// helperCommand re-runs the test binary as a stand-in for some external command.
+func helperCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=^TestCommandHelperProcess$", "--"}, args...)...)
+	cmd.Env = append(os.Environ(), "EXPECT_COMMAND_HELPER=1")
+	return cmd
+}
+
+func TestCommandHelperProcess(t *testing.T) {
+	if os.Getenv("EXPECT_COMMAND_HELPER") != "1" {
+		return
+	}
+
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	args = args[1:]
+
+	switch args[0] {
+	case "echo":
+		fmt.Println(args[1])
+	case "fail":
+		fmt.Fprintln(os.Stderr, args[1])
+		code, _ := strconv.Atoi(args[2])
+		os.Exit(code)
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	}
+	os.Exit(0)
+}
+
+func TestCommandToSucceed(t *testing.T) {
+	c := &capture{}
+
+	expect.Command(helperCommand("echo", "hello")).ToSucceed(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Command(helperCommand("fail", "oops", "3")).Not().ToSucceed(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Command(helperCommand("fail", "oops", "3")).I("my cmd").ToSucceed(c)
+	c.shouldHaveCalledErrorfRE(t, `^Expected my cmd command ―――\n\S+ -test.run=\S+ -- fail oops 3\n`+
+		`――― to succeed but it exited with status 3; stdout ―――\n""\n――― stderr ―――\noops\n$`)
+
+	expect.Command(exec.Command("/no/such/command")).ToSucceed(c)
+	c.shouldHaveCalledErrorfRE(t, `^Expected command ―――\n/no/such/command\n`+
+		`――― to succeed but it could not be run ―――\n.*no such file or directory\n$`)
+}
+
+func TestCommandToExitWith(t *testing.T) {
+	c := &capture{}
+
+	expect.Command(helperCommand("fail", "oops", "3")).ToExitWith(c, 3)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Command(helperCommand("fail", "oops", "3")).ToExitWith(c, 4)
+	c.shouldHaveCalledErrorfRE(t, `^Expected command ―――\n.+\n`+
+		`――― to exit with status 4 but it exited with status 3; stdout ―――\n""\n――― stderr ―――\noops\n$`)
+
+	expect.Command(helperCommand("sleep")).Timeout(100*time.Millisecond).ToExitWith(c, 0)
+	c.shouldHaveCalledErrorfRE(t, `^Expected command ―――\n.+\n`+
+		`――― to exit with status 0 but it timed out after 100ms; stdout ―――\n""\n――― stderr ―――\n""\n$`)
+}
+
+func TestCommandStdout(t *testing.T) {
+	c := &capture{}
+
+	cmd := expect.Command(helperCommand("echo", "hello world")).I("my cmd")
+	cmd.ToSucceed(c)
+	c.shouldNotHaveHadAnError(t)
+
+	cmd.Stdout().ToBe(c, "hello world\n")
+	c.shouldNotHaveHadAnError(t)
+
+	cmd.Stderr().ToBeEmpty(c)
+	c.shouldNotHaveHadAnError(t)
+
+	cmd.Stdout().ToContain(c, "goodbye")
+	c.shouldHaveCalledErrorf(t, "Expected stdout of my cmd string len:12 ―――\nhello world␤\n\n――― to contain ―――\ngoodbye\n")
+}
+
+func ExampleCommand() {
+	var t *testing.T
+
+	cmd := expect.Command(exec.Command("./mytool", "--version")).Timeout(5 * time.Second)
+	cmd.ToSucceed(t)
+	cmd.Stdout().ToContain(t, "v1.")
+}
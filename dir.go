@@ -0,0 +1,321 @@
+package expect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rickb777/plural"
+)
+
+// UpdateGolden causes [DirType.ToMatchGoldenDir] to rewrite the golden directory from the
+// actual tree instead of comparing them. It is typically set from a test flag, e.g.
+//
+//	func init() {
+//		flag.BoolVar(&expect.UpdateGolden, "update", false, "rewrite golden files")
+//	}
+var UpdateGolden = false
+
+// DirType is used for assertions about directory trees.
+type DirType struct {
+	fsys        fs.FS
+	ignore      []string
+	ignoreModes bool
+	assertion
+}
+
+// Dir creates an assertion about a directory tree held in a file system such as [os.DirFS]
+// or [testing/fstest.MapFS].
+func Dir(fsys fs.FS) DirType {
+	return DirType{fsys: fsys}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a DirType) Info(info any, other ...any) DirType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a DirType) I(info any, other ...any) DirType {
+	return a.Info(info, other...)
+}
+
+// Ignore skips files and directories that match any of the patterns (see [path.Match]). Each
+// pattern is matched against both the full slash-separated path and the base name. When a
+// directory is ignored, so is everything inside it.
+func (a DirType) Ignore(pattern ...string) DirType {
+	a.ignore = append(slices.Clip(a.ignore), pattern...)
+	return a
+}
+
+// IgnoreModes prevents file modes being compared. Without this, the permission bits of files
+// are compared whenever they are non-zero in both trees, so [testing/fstest.MapFS] files with
+// no mode will match any mode. The modes of directories are never compared.
+func (a DirType) IgnoreModes() DirType {
+	a.ignoreModes = true
+	return a
+}
+
+// Not inverts the assertion.
+func (a DirType) Not() DirType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToMatchTree asserts that the directory tree has the same files and directories as the
+// expected tree, with the same contents and modes (see [DirType.IgnoreModes]). Files that are
+// added, missing or changed are all listed; changed text files are shown with their differences.
+// The tester is normally [*testing.T].
+func (a DirType) ToMatchTree(t Tester, expected fs.FS) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	actualTree, err := a.readTree(a.fsys)
+	if err != nil {
+		a.fault = describeTreeError("the directory", err)
+	}
+
+	expectedTree, err := a.readTree(expected)
+	if err != nil && a.fault == "" {
+		a.fault = describeTreeError("the expected tree", err)
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	diffs := a.diffTrees(actualTree, expectedTree)
+
+	if !a.not && len(diffs) > 0 {
+		a.describeActualExpected1("directory tree to match but %s ―――\n%s", thereWereNDifferences.FormatInt(len(diffs)), strings.Join(diffs, ""))
+	} else if a.not && len(diffs) == 0 {
+		a.describeActualExpected1("directory tree not to match but both trees have the same %s.\n", nEntries.FormatInt(len(actualTree)))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+var nEntries = plural.FromOne("one entry", "%d entries")
+
+//-------------------------------------------------------------------------------------------------
+
+// ToMatchGoldenDir asserts that the directory tree matches the golden directory on disk, as for
+// [DirType.ToMatchTree]. If [UpdateGolden] is true, the golden directory is rewritten to match
+// the actual tree instead: files are written, missing files are removed and ignored files are
+// left alone.
+// The tester is normally [*testing.T].
+func (a DirType) ToMatchGoldenDir(t Tester, dir string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if UpdateGolden {
+		if err := a.updateGolden(dir); err != nil {
+			t.Fatal(fmt.Sprintf("Expected%s golden directory %s to be updated but %v.\n", preS(a.info), dir, err))
+		}
+	}
+
+	a.ToMatchTree(t, os.DirFS(dir))
+}
+
+func (a DirType) updateGolden(dir string) error {
+	actualTree, err := a.readTree(a.fsys)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	goldenTree, err := a.readTree(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	// remove stale files first, deepest first, so that directories are empty when removed
+	stale := make([]string, 0, len(goldenTree))
+	for name, e := range goldenTree {
+		if ae, exists := actualTree[name]; !exists || ae.isDir != e.isDir {
+			stale = append(stale, name)
+		}
+	}
+	slices.Sort(stale)
+	for _, name := range slices.Backward(stale) {
+		if err = os.RemoveAll(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedTreeNames(actualTree) {
+		e := actualTree[name]
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if e.isDir {
+			err = os.MkdirAll(filename, 0o755)
+		} else {
+			perm := e.mode.Perm()
+			if perm == 0 {
+				perm = 0o644
+			}
+			err = os.WriteFile(filename, e.data, perm)
+			if err == nil {
+				err = os.Chmod(filename, perm)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+type treeEntry struct {
+	isDir bool
+	mode  fs.FileMode
+	data  []byte
+}
+
+func (a DirType) readTree(fsys fs.FS) (map[string]treeEntry, error) {
+	tree := make(map[string]treeEntry)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+
+		if a.ignored(name) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		e := treeEntry{isDir: d.IsDir(), mode: info.Mode()}
+		if !e.isDir {
+			e.data, err = fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+		}
+		tree[name] = e
+		return nil
+	})
+
+	return tree, err
+}
+
+// describeTreeError describes a tree that could not be read, taking care to report a missing
+// root clearly rather than as an empty tree.
+func describeTreeError(which string, err error) string {
+	var pe *fs.PathError
+	if errors.As(err, &pe) && pe.Path == "." && errors.Is(err, fs.ErrNotExist) {
+		return which + " does not exist"
+	}
+	return fmt.Sprintf("%s could not be read: %v", which, err)
+}
+
+func (a DirType) ignored(name string) bool {
+	base := path.Base(name)
+	for _, p := range a.ignore {
+		if m, _ := path.Match(p, name); m {
+			return true
+		}
+		if m, _ := path.Match(p, base); m {
+			return true
+		}
+	}
+	return false
+}
+
+func (a DirType) diffTrees(actual, expected map[string]treeEntry) []string {
+	names := sortedTreeNames(actual)
+	for name := range expected {
+		if _, exists := actual[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var diffs []string
+	for _, name := range names {
+		ac, inActual := actual[name]
+		ex, inExpected := expected[name]
+
+		switch {
+		case !inExpected:
+			diffs = append(diffs, fmt.Sprintf("added   %s%s\n", name, describeEntry(ac)))
+
+		case !inActual:
+			diffs = append(diffs, fmt.Sprintf("missing %s%s\n", name, describeEntry(ex)))
+
+		case ac.isDir != ex.isDir:
+			diffs = append(diffs, fmt.Sprintf("changed %s is %s, want %s\n", name, ac.mode, ex.mode))
+
+		default:
+			if !a.ignoreModes && !ac.isDir && ac.mode.Perm() != 0 && ex.mode.Perm() != 0 && ac.mode.Perm() != ex.mode.Perm() {
+				diffs = append(diffs, fmt.Sprintf("changed %s mode %s, want %s\n", name, ac.mode, ex.mode))
+			}
+			if !ac.isDir && !bytes.Equal(ac.data, ex.data) {
+				diffs = append(diffs, fmt.Sprintf("changed %s %s", name, describeContentDiff(ac.data, ex.data)))
+			}
+		}
+	}
+
+	return diffs
+}
+
+func describeEntry(e treeEntry) string {
+	if e.isDir {
+		return "/"
+	}
+	return fmt.Sprintf(" (%d bytes)", len(e.data))
+}
+
+func describeContentDiff(actual, expected []byte) string {
+	if !utf8.Valid(actual) || !utf8.Valid(expected) {
+		return fmt.Sprintf("content (%d bytes, want %d bytes)\n", len(actual), len(expected))
+	}
+
+	diffs := diffLines(splitLines(string(expected)), splitLines(string(actual)))
+	if len(diffs) == 0 {
+		return fmt.Sprintf("content: %s\n", finalNewlineDiff(string(expected), string(actual)))
+	}
+
+	diff, line, column := findFirstRuneDiff([]rune(string(actual)), []rune(string(expected)))
+	return fmt.Sprintf("content (-want, +got) ―――\n%s%s",
+		strings.Join(diffs, ""), firstDifferenceInfo("rune", diff, line, column))
+}
+
+func sortedTreeNames(tree map[string]treeEntry) []string {
+	names := make([]string, 0, len(tree))
+	for name := range tree {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
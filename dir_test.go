@@ -0,0 +1,146 @@
+package expect_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rickb777/expect"
+)
+
+var scaffold = fstest.MapFS{
+	"README.md":       {Data: []byte("# demo\n")},
+	"cmd/main.go":     {Data: []byte("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"), Mode: 0o644},
+	"cmd/run.sh":      {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+	"internal":        {Mode: fs.ModeDir | 0o755},
+	"build/out.bin":   {Data: []byte{0xff, 0x00}},
+	"build/debug.log": {Data: []byte("noise")},
+}
+
+func TestDirToMatchTree(t *testing.T) {
+	c := &capture{}
+
+	expect.Dir(scaffold).ToMatchTree(c, scaffold)
+	c.shouldNotHaveHadAnError(t)
+
+	expected := fstest.MapFS{
+		"README.md":   {Data: []byte("# demo\n")},
+		"cmd/main.go": {Data: []byte("package main\n\nfunc main() {\n\tprintln(\"goodbye\")\n}\n")},
+		"cmd/run.sh":  {Data: []byte("#!/bin/sh\n"), Mode: 0o644},
+		"LICENSE":     {Data: []byte("MIT\n")},
+		"internal":    {Mode: fs.ModeDir},
+	}
+
+	expect.Dir(scaffold).Ignore("build").ToMatchTree(c, expected)
+	c.shouldHaveCalledErrorf(t, `Expected directory tree to match but there were 3 differences ―――
+missing LICENSE (4 bytes)
+changed cmd/main.go content (-want, +got) ―――
+@@ line 2 @@
+  
+  func main() {
+- 	println("goodbye")
++ 	println("hello")
+  }
+――― the first difference is at rune 38 (line 4:11).
+changed cmd/run.sh mode -rwxr-xr-x, want -rw-r--r--
+`)
+
+	expect.Dir(scaffold).Ignore("build", "*.sh", "cmd/main.go").ToMatchTree(c, expected)
+	c.shouldHaveCalledErrorf(t, `Expected directory tree to match but there was one difference ―――
+missing LICENSE (4 bytes)
+`)
+
+	expect.Dir(scaffold).Ignore("*.log").IgnoreModes().I("scaffold").ToMatchTree(c, fstest.MapFS{
+		"README.md":     {Data: []byte("# demo\n")},
+		"cmd":           {Mode: fs.ModeDir},
+		"cmd/main.go":   {Mode: fs.ModeDir},
+		"cmd/run.sh":    {Data: []byte("#!/bin/sh\n")},
+		"build/out.bin": {Data: []byte{0xff}},
+	})
+	c.shouldHaveCalledErrorf(t, `Expected scaffold directory tree to match but there were 3 differences ―――
+changed build/out.bin content (2 bytes, want 1 bytes)
+changed cmd/main.go is -rw-r--r--, want d---------
+added   internal/
+`)
+
+	expect.Dir(scaffold).Not().ToMatchTree(c, scaffold)
+	c.shouldHaveCalledErrorf(t, "Expected directory tree not to match but both trees have the same 8 entries.\n")
+
+	expect.Dir(fstest.MapFS{"a.txt": {Data: []byte("hi")}}).ToMatchTree(c, fstest.MapFS{"a.txt": {Data: []byte("hi\n")}})
+	c.shouldHaveCalledErrorf(t, `Expected directory tree to match but there was one difference ―――
+changed a.txt content: the final newline is missing
+`)
+}
+
+func TestDirMissing(t *testing.T) {
+	c := &capture{}
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	expect.Dir(os.DirFS(missing)).ToMatchTree(c, fstest.MapFS{})
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the directory does not exist.\n")
+
+	expect.Dir(fstest.MapFS{}).ToMatchTree(c, os.DirFS(missing))
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the expected tree does not exist.\n")
+
+	expect.UpdateGolden = true
+	expect.Dir(fstest.MapFS{}).ToMatchGoldenDir(c, missing)
+	expect.UpdateGolden = false
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(missing).ToBeDir(c)
+	c.shouldNotHaveHadAnError(t)
+}
+
+func TestDirToMatchGoldenDir(t *testing.T) {
+	c := &capture{}
+
+	golden := filepath.Join(t.TempDir(), "golden")
+	if err := os.MkdirAll(filepath.Join(golden, "stale"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(golden, "stale", "old.txt"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(golden, "debug.log"), []byte("kept"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expect.Dir(scaffold).Ignore("build", "*.log").ToMatchGoldenDir(c, golden)
+	if c.errorfCalls == 0 {
+		t.Errorf("expected differences before updating")
+	}
+	c.reset()
+
+	expect.UpdateGolden = true
+	expect.Dir(scaffold).Ignore("build", "*.log").ToMatchGoldenDir(c, golden)
+	expect.UpdateGolden = false
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Dir(scaffold).Ignore("build", "*.log").ToMatchGoldenDir(c, golden)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(filepath.Join(golden, "stale")).Not().ToExist(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(filepath.Join(golden, "debug.log")).String().ToBe(c, "kept")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(filepath.Join(golden, "cmd", "run.sh")).ToHaveMode(c, 0o755)
+	c.shouldNotHaveHadAnError(t)
+}
+
+func ExampleDir() {
+	var t *testing.T
+
+	// ... code under test generates a project in "out"
+
+	expect.Dir(os.DirFS("out")).Ignore("*.log").ToMatchTree(t, fstest.MapFS{
+		"go.mod":  {Data: []byte("module demo\n")},
+		"main.go": {Data: []byte("package main\n")},
+	})
+
+	// or compare with testdata/golden, which is rewritten when expect.UpdateGolden is set
+	expect.Dir(os.DirFS("out")).ToMatchGoldenDir(t, "testdata/golden")
+}
@@ -0,0 +1,122 @@
+package expect
+
+import (
+	"time"
+)
+
+// DurationType is used for assertions about durations.
+type DurationType struct {
+	actual time.Duration
+	assertion
+}
+
+// Duration creates an assertion about a [time.Duration]. Failure messages render durations
+// using [time.Duration.String].
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Duration(value time.Duration, other ...any) DurationType {
+	return DurationType{actual: value, assertion: assertion{otherActual: other}}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a DurationType) Info(info any, other ...any) DurationType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a DurationType) I(info any, other ...any) DurationType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a DurationType) Not() DurationType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBe asserts that the actual and expected durations are equal.
+// The tester is normally [*testing.T].
+func (a DurationType) ToBe(t Tester, expected time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual == expected, "to be", expected)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeLessThan asserts that the actual duration is less than the threshold.
+// The tester is normally [*testing.T].
+func (a DurationType) ToBeLessThan(t Tester, threshold time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual < threshold, "to be less than", threshold)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeGreaterThan asserts that the actual duration is greater than the threshold.
+// The tester is normally [*testing.T].
+func (a DurationType) ToBeGreaterThan(t Tester, threshold time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual > threshold, "to be greater than", threshold)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a DurationType) toCompare(t Tester, match bool, what string, expected time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("duration ―――\n%s\n", a.actual)
+		a.addExpectation("%s ―――\n%s\n", what, expected)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeWithin asserts that the actual duration differs from the expected duration
+// by no more than the tolerance.
+// The tester is normally [*testing.T].
+func (a DurationType) ToBeWithin(t Tester, tolerance, expected time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	difference := a.actual - expected
+	match := difference.Abs() <= tolerance
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("duration ―――\n%s\n", a.actual)
+		a.addExpectation("to be within %s of ―――\n%s\n――― the difference is %s.\n",
+			tolerance, expected, difference)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
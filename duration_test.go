@@ -0,0 +1,60 @@
+package expect_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+func TestDurationToBe(t *testing.T) {
+	c := &capture{}
+
+	expect.Duration(90*time.Second).ToBe(c, time.Minute+30*time.Second)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Duration(90*time.Second).I("timeout").ToBe(c, time.Minute)
+	c.shouldHaveCalledErrorf(t, "Expected timeout duration ―――\n1m30s\n――― to be ―――\n1m0s\n")
+
+	expect.Duration(time.Minute).Not().ToBe(c, time.Minute)
+	c.shouldHaveCalledErrorf(t, "Expected duration ―――\n1m0s\n――― not to be ―――\n1m0s\n")
+}
+
+func TestDurationToBeLessOrGreater(t *testing.T) {
+	c := &capture{}
+
+	expect.Duration(time.Second).ToBeLessThan(c, time.Minute)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Duration(time.Minute).ToBeGreaterThan(c, time.Second)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Duration(time.Minute).ToBeLessThan(c, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected duration ―――\n1m0s\n――― to be less than ―――\n1s\n")
+
+	expect.Duration(time.Second).ToBeGreaterThan(c, time.Minute)
+	c.shouldHaveCalledErrorf(t, "Expected duration ―――\n1s\n――― to be greater than ―――\n1m0s\n")
+}
+
+func TestDurationToBeWithin(t *testing.T) {
+	c := &capture{}
+
+	expect.Duration(950*time.Millisecond).ToBeWithin(c, 100*time.Millisecond, time.Second)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Duration(1200*time.Millisecond).ToBeWithin(c, 100*time.Millisecond, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected duration ―――\n1.2s\n――― to be within 100ms of ―――\n1s\n――― the difference is 200ms.\n")
+
+	expect.Duration(time.Second).Not().ToBeWithin(c, 100*time.Millisecond, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected duration ―――\n1s\n――― not to be within 100ms of ―――\n1s\n――― the difference is 0s.\n")
+}
+
+func ExampleDurationType_ToBeWithin() {
+	var t *testing.T
+
+	start := time.Now()
+	// ... something under test goes here
+	elapsed := time.Since(start)
+
+	expect.Duration(elapsed).ToBeWithin(t, 50*time.Millisecond, 100*time.Millisecond)
+}
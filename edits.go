@@ -0,0 +1,156 @@
+package expect
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rickb777/plural"
+)
+
+// edit is one step of an edit script that turns an expected sequence into an actual one.
+// The op is ' ' when both sequences have an equal item, '-' when an expected item is missing
+// and '+' when an actual item is unexpected. The indexes are -1 where not applicable.
+type edit struct {
+	op     byte
+	ex, ac int
+}
+
+// maxEditCells limits the work done by editScript; beyond this, the differing region is
+// reported as wholly removed and then wholly added.
+const maxEditCells = 4_000_000
+
+// editScript aligns two sequences using their longest common subsequence. Where items
+// differ, removed items come before added ones.
+func editScript[T any](expected, actual []T, equal func(T, T) bool) []edit {
+	// trim the common prefix and suffix
+	pre := 0
+	for pre < len(expected) && pre < len(actual) && equal(expected[pre], actual[pre]) {
+		pre++
+	}
+	suf := 0
+	for suf < len(expected)-pre && suf < len(actual)-pre && equal(expected[len(expected)-1-suf], actual[len(actual)-1-suf]) {
+		suf++
+	}
+
+	script := make([]edit, 0, max(len(expected), len(actual)))
+	for k := range pre {
+		script = append(script, edit{' ', k, k})
+	}
+
+	ex := expected[pre : len(expected)-suf]
+	ac := actual[pre : len(actual)-suf]
+
+	if (len(ex)+1)*(len(ac)+1) > maxEditCells {
+		for i := range ex {
+			script = append(script, edit{'-', pre + i, -1})
+		}
+		for j := range ac {
+			script = append(script, edit{'+', -1, pre + j})
+		}
+	} else {
+		lcs := make([][]int, len(ex)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(ac)+1)
+		}
+		for i := len(ex) - 1; i >= 0; i-- {
+			for j := len(ac) - 1; j >= 0; j-- {
+				if equal(ex[i], ac[j]) {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(ex) || j < len(ac) {
+			switch {
+			case i < len(ex) && j < len(ac) && equal(ex[i], ac[j]):
+				script = append(script, edit{' ', pre + i, pre + j})
+				i++
+				j++
+			case i < len(ex) && (j == len(ac) || lcs[i+1][j] >= lcs[i][j+1]):
+				script = append(script, edit{'-', pre + i, -1})
+				i++
+			default:
+				script = append(script, edit{'+', -1, pre + j})
+				j++
+			}
+		}
+	}
+
+	for k := range suf {
+		script = append(script, edit{' ', len(expected) - suf + k, len(actual) - suf + k})
+	}
+
+	return script
+}
+
+//-------------------------------------------------------------------------------------------------
+
+var thereWereNDifferingLines = plural.FromOne("there was one differing line", "there were %d differing lines")
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines compares two sequences of lines, returning the differences with two lines of
+// context, each prefixed by "- ", "+ " or "  ". Each hunk starts with a line number marker.
+// The result is empty when there are no differences.
+func diffLines(expected, actual []string) []string {
+	script := editScript(expected, actual, func(e, a string) bool { return e == a })
+	if !slices.ContainsFunc(script, func(e edit) bool { return e.op != ' ' }) {
+		return nil
+	}
+
+	// show the changes plus the unchanged lines close to them
+	const context = 2
+	shown := make([]bool, len(script))
+	for k, e := range script {
+		if e.op != ' ' {
+			for c := max(0, k-context); c <= min(len(script)-1, k+context); c++ {
+				shown[c] = true
+			}
+		}
+	}
+
+	var result []string
+	line := 1 // the line number in the expected text
+	for k, e := range script {
+		if shown[k] {
+			if k == 0 || !shown[k-1] {
+				result = append(result, fmt.Sprintf("@@ line %d @@\n", line))
+			}
+			if e.op == '+' {
+				result = append(result, "+ "+actual[e.ac]+"\n")
+			} else {
+				result = append(result, fmt.Sprintf("%c %s\n", e.op, expected[e.ex]))
+			}
+		}
+		if e.op != '+' {
+			line++
+		}
+	}
+
+	return result
+}
+
+// finalNewlineDiff describes texts that differ only in their final newline, which
+// diffLines does not show.
+func finalNewlineDiff(expected, actual string) string {
+	if strings.HasSuffix(expected, "\n") {
+		return "the final newline is missing"
+	}
+	return "there is an extra final newline"
+}
+
+func countChangedLines(diffs []string) int {
+	n := 0
+	for _, d := range diffs {
+		if d[0] == '-' || d[0] == '+' {
+			n++
+		}
+	}
+	return n
+}
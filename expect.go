@@ -1,7 +1,9 @@
 package expect
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"reflect"
@@ -51,6 +53,8 @@ type assertion struct {
 	actualDescription string
 	actualSeparator   bool
 	moreMessages      []string
+	fault             string
+	inert             bool // assertions are skipped, e.g. when there was nothing to assert about
 }
 
 func (a *assertion) describeActual(message string, args ...any) {
@@ -75,7 +79,7 @@ func (a *assertion) addExpectation(message string, args ...any) {
 }
 
 func (a *assertion) applyAll(t Tester) {
-	if t != nil && a.passes == 0 {
+	if t != nil && a.passes == 0 && !a.inert {
 		if h, ok := t.(helper); ok {
 			h.Helper()
 		}
@@ -105,11 +109,15 @@ func join(before string, messages []string, separator string) string {
 //-------------------------------------------------------------------------------------------------
 
 func (a *assertion) allOtherArgumentsMustNotBeError(t Tester) {
-	if a != nil {
+	if a != nil && !a.inert {
 		if h, ok := t.(helper); ok {
 			h.Helper()
 		}
 
+		if a.fault != "" {
+			t.Fatal(fmt.Sprintf("Expected%s to be usable but %s.\n", preS(a.info), a.fault))
+		}
+
 		for i, o := range a.otherActual {
 			switch o.(type) {
 			case error:
@@ -218,6 +226,30 @@ func isBuiltIn(k reflect.Kind) bool {
 
 //-------------------------------------------------------------------------------------------------
 
+// readerOf accepts a []byte, string or [io.Reader], including subtypes of []byte and string.
+func readerOf(data any) (io.Reader, error) {
+	switch d := data.(type) {
+	case io.Reader:
+		return d, nil
+	case []byte:
+		return bytes.NewReader(d), nil
+	case string:
+		return strings.NewReader(d), nil
+	}
+
+	rv := reflect.ValueOf(data)
+	switch {
+	case rv.Kind() == reflect.String:
+		return strings.NewReader(rv.String()), nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return bytes.NewReader(rv.Bytes()), nil
+	}
+
+	return nil, fmt.Errorf("%T is not []byte, string or io.Reader", data)
+}
+
+//-------------------------------------------------------------------------------------------------
+
 func findFirstRuneDiff(a, b []rune) (diff, line, column int) {
 	line = 1
 	column = 1
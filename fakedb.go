@@ -0,0 +1,88 @@
+package expect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FakeResult is the scripted result of a query on a database opened by [OpenFakeDB].
+type FakeResult struct {
+	Columns []string
+	Rows    [][]driver.Value
+	Err     error // returned instead of the row after the last row, if not nil
+}
+
+// OpenFakeDB opens a database whose queries return scripted results, so that code using
+// [Rows] can be tested without a real database. Each query string maps to a result, which
+// is returned whatever the arguments are; other queries fail. Exec reports the number of
+// rows in the result as the rows affected. Transactions are not supported.
+func OpenFakeDB(results map[string]FakeResult) *sql.DB {
+	return sql.OpenDB(fakeDriver{results: results})
+}
+
+// fakeDriver is the database driver used by OpenFakeDB.
+type fakeDriver struct {
+	results map[string]FakeResult
+}
+
+// Connect implements driver.Connector.
+func (d fakeDriver) Connect(context.Context) (driver.Conn, error) { return fakeConn(d), nil }
+
+// Driver implements driver.Connector.
+func (d fakeDriver) Driver() driver.Driver { return d }
+
+// Open implements driver.Driver.
+func (d fakeDriver) Open(string) (driver.Conn, error) { return fakeConn(d), nil }
+
+type fakeConn fakeDriver
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	result, ok := c.results[query]
+	if !ok {
+		return nil, fmt.Errorf("fake driver has no result for %q", query)
+	}
+	return fakeStmt(result), nil
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake driver: transactions not supported")
+}
+
+type fakeStmt FakeResult
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(len(s.Rows)), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{result: FakeResult(s)}, nil
+}
+
+type fakeRows struct {
+	result FakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.Columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.Rows) {
+		if r.result.Err != nil {
+			return r.result.Err
+		}
+		return io.EOF
+	}
+	copy(dest, r.result.Rows[r.next])
+	r.next++
+	return nil
+}
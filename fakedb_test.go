@@ -0,0 +1,41 @@
+package expect_test
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func TestOpenFakeDB(t *testing.T) {
+	c := &capture{}
+
+	db := expect.OpenFakeDB(map[string]expect.FakeResult{
+		"DELETE FROM fruit": {Rows: [][]driver.Value{{"apple"}, {"pear"}}},
+	})
+
+	result, err := db.Exec("DELETE FROM fruit")
+	expect.Error(err).Not().ToHaveOccurred(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Number(result.RowsAffected()).ToBe(c, 2)
+	c.shouldNotHaveHadAnError(t)
+
+	_, err = db.Query("SELECT * FROM veg")
+	expect.Error(err).ToHaveOccurred(c)
+	c.shouldNotHaveHadAnError(t)
+
+	_, err = db.Begin()
+	expect.Error(err).ToContain(c, "transactions not supported")
+	c.shouldNotHaveHadAnError(t)
+}
+
+func ExampleOpenFakeDB() {
+	var t *testing.T
+
+	db := expect.OpenFakeDB(map[string]expect.FakeResult{
+		"SELECT name FROM fruit": {Columns: []string{"name"}, Rows: [][]driver.Value{{"apple"}}},
+	})
+
+	expect.Rows(db.Query("SELECT name FROM fruit")).ToContainRow(t, "apple")
+}
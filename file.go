@@ -0,0 +1,231 @@
+package expect
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileType is used for assertions about files, either in the operating system's file system
+// or in an [fs.FS].
+type FileType struct {
+	fsys    fs.FS
+	name    string
+	display string
+	parent  string
+	assertion
+}
+
+// File creates an assertion about a file in the operating system's file system.
+func File(filename string) FileType {
+	return FileType{
+		fsys:    os.DirFS(filepath.Dir(filename)),
+		name:    filepath.Base(filename),
+		display: filename,
+		parent:  filepath.Dir(filename),
+	}
+}
+
+// FileIn creates an assertion about a file in a file system such as [os.DirFS], [embed.FS]
+// or [testing/fstest.MapFS]. The name must be a valid path within fsys (see [fs.ValidPath]).
+func FileIn(fsys fs.FS, name string) FileType {
+	return FileType{
+		fsys:    fsys,
+		name:    name,
+		display: name,
+		parent:  path.Dir(name),
+	}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a FileType) Info(info any, other ...any) FileType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a FileType) I(info any, other ...any) FileType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a FileType) Not() FileType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToExist asserts that the file (or directory) exists.
+// The tester is normally [*testing.T].
+func (a FileType) ToExist(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	_, err := fs.Stat(a.fsys, a.name)
+
+	if !a.not && err != nil {
+		a.describeMissing(err)
+	} else if a.not && err == nil {
+		a.describeActualExpected1("file %s not to exist.\n", a.display)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeDir asserts that the file exists and is a directory.
+// The tester is normally [*testing.T].
+func (a FileType) ToBeDir(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	info, err := fs.Stat(a.fsys, a.name)
+
+	if err != nil {
+		a.describeMissing(err)
+	} else if info.IsDir() == a.not {
+		a.describeActualExpected1("file %s %sto be a directory but its mode is %s.\n", a.display, notS(a.not), info.Mode())
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveMode asserts that the file exists and has the expected mode. Only the permission bits are
+// compared unless the expected mode also includes type bits such as [fs.ModeDir].
+// The tester is normally [*testing.T].
+func (a FileType) ToHaveMode(t Tester, mode fs.FileMode) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	info, err := fs.Stat(a.fsys, a.name)
+
+	if err != nil {
+		a.describeMissing(err)
+		a.applyAll(t)
+		return
+	}
+
+	actual := info.Mode()
+	if mode.Type() == 0 {
+		actual = actual.Perm()
+	}
+
+	if (!a.not && actual != mode) || (a.not && actual == mode) {
+		a.describeActualExpected1("file %s %sto have mode %s but it was %s.\n", a.display, notS(a.not), mode, info.Mode())
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveSize asserts that the file exists and has the expected size in bytes.
+// The tester is normally [*testing.T].
+func (a FileType) ToHaveSize(t Tester, size int64) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	info, err := fs.Stat(a.fsys, a.name)
+
+	if err != nil {
+		a.describeMissing(err)
+	} else if (!a.not && info.Size() != size) || (a.not && info.Size() == size) {
+		a.describeActualExpected1("file %s %sto have size %d but it was %d.\n", a.display, notS(a.not), size, info.Size())
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String passes the content of the file to a [String] assertion.
+func (a FileType) String() *StringType[string] {
+	b, fault := a.read()
+	s := String(string(b)).Info(a.contentInfo())
+	s.fault = fault
+	return s
+}
+
+// Bytes passes the content of the file to a [Slice] assertion.
+func (a FileType) Bytes() SliceType[byte] {
+	b, fault := a.read()
+	s := Slice(b).Info(a.contentInfo())
+	s.fault = fault
+	return s
+}
+
+func (a FileType) contentInfo() string {
+	if a.info == "" {
+		return "file " + a.display
+	}
+	return a.info + " file " + a.display
+}
+
+func (a FileType) read() ([]byte, string) {
+	b, err := fs.ReadFile(a.fsys, a.name)
+	if err != nil {
+		return nil, fmt.Sprintf("it could not be read: %v", unwrapPathError(err))
+	}
+	return b, ""
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// describeMissing explains why the file could not be found, listing the parent directory.
+func (a *FileType) describeMissing(err error) {
+	if !errors.Is(err, fs.ErrNotExist) {
+		a.describeActualExpected1("file %s to exist but it could not be read: %v.\n", a.display, unwrapPathError(err))
+		return
+	}
+
+	entries, err := fs.ReadDir(a.fsys, path.Dir(a.name))
+	if err != nil {
+		a.describeActualExpected1("file %s to exist but neither it nor its directory %s exists.\n", a.display, a.parent)
+		return
+	}
+
+	if len(entries) == 0 {
+		a.describeActualExpected1("file %s to exist but directory %s is empty.\n", a.display, a.parent)
+		return
+	}
+
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name()
+		if e.IsDir() {
+			names[i] += "/"
+		}
+	}
+	a.describeActualExpected1("file %s to exist but directory %s contains ―――\n%s\n", a.display, a.parent, strings.Join(names, "\n"))
+}
+
+func unwrapPathError(err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		return pe.Err
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package expect_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rickb777/expect"
+)
+
+var fruitFS = fstest.MapFS{
+	"fruit/apple.txt": {Data: []byte("apple\n"), Mode: 0o644},
+	"fruit/pear.txt":  {Data: []byte("pear\n"), Mode: 0o600},
+	"fruit/stone":     {Mode: fs.ModeDir | 0o755},
+}
+
+func TestFileToExist(t *testing.T) {
+	c := &capture{}
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").ToExist(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/plum.txt").Not().ToExist(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/plum.txt").I("export").ToExist(c)
+	c.shouldHaveCalledErrorf(t, `Expected export file fruit/plum.txt to exist but directory fruit contains ―――
+apple.txt
+pear.txt
+stone/
+`)
+
+	expect.FileIn(fruitFS, "veg/leek.txt").ToExist(c)
+	c.shouldHaveCalledErrorf(t, "Expected file veg/leek.txt to exist but neither it nor its directory veg exists.\n")
+
+	expect.FileIn(fruitFS, "fruit/stone").Not().ToExist(c)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/stone not to exist.\n")
+}
+
+func TestFileToBeDir(t *testing.T) {
+	c := &capture{}
+
+	expect.FileIn(fruitFS, "fruit/stone").ToBeDir(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").Not().ToBeDir(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").ToBeDir(c)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/apple.txt to be a directory but its mode is -rw-r--r--.\n")
+
+	expect.FileIn(fruitFS, "fruit/stone").Not().ToBeDir(c)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/stone not to be a directory but its mode is drwxr-xr-x.\n")
+}
+
+func TestFileToHaveModeAndSize(t *testing.T) {
+	c := &capture{}
+
+	expect.FileIn(fruitFS, "fruit/pear.txt").ToHaveMode(c, 0o600)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/stone").ToHaveMode(c, fs.ModeDir|0o755)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/pear.txt").ToHaveMode(c, 0o644)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/pear.txt to have mode -rw-r--r-- but it was -rw-------.\n")
+
+	expect.FileIn(fruitFS, "fruit/pear.txt").ToHaveSize(c, 5)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/pear.txt").ToHaveSize(c, 4)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/pear.txt to have size 4 but it was 5.\n")
+
+	expect.FileIn(fruitFS, "fruit/pear.txt").Not().ToHaveSize(c, 5)
+	c.shouldHaveCalledErrorf(t, "Expected file fruit/pear.txt not to have size 5 but it was 5.\n")
+}
+
+func TestFileContent(t *testing.T) {
+	c := &capture{}
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").String().ToBe(c, "apple\n")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").Bytes().ToHaveLength(c, 6)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FileIn(fruitFS, "fruit/apple.txt").I("export").String().ToContain(c, "pear")
+	c.shouldHaveCalledErrorf(t, `Expected export file fruit/apple.txt string len:6 ―――
+apple␤
+
+――― to contain ―――
+pear
+`)
+
+	expect.FileIn(fruitFS, "fruit/plum.txt").String().Not().ToBe(c, "x")
+	c.shouldHaveCalledFatalf(t, "Expected file fruit/plum.txt to be usable but it could not be read: file does not exist.\n")
+}
+
+func TestFileInOS(t *testing.T) {
+	c := &capture{}
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "hello.txt")
+	if err := os.WriteFile(name, []byte("hello"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	expect.File(name).ToExist(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(name).ToHaveMode(c, 0o640)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(name).String().ToBe(c, "hello")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.File(dir).ToBeDir(c)
+	c.shouldNotHaveHadAnError(t)
+
+	missing := filepath.Join(dir, "goodbye.txt")
+	expect.File(missing).ToExist(c)
+	c.shouldHaveCalledErrorf(t, "Expected file "+missing+" to exist but directory "+dir+" contains ―――\nhello.txt\n")
+}
+
+func ExampleFile() {
+	var t *testing.T
+
+	// ... code under test writes "out/report.csv"
+
+	expect.File("out/report.csv").ToExist(t)
+	expect.File("out/report.csv").String().ToContain(t, "total")
+
+	// any fs.FS can be used, such as embed.FS or fstest.MapFS
+	fsys := os.DirFS("out")
+	expect.FileIn(fsys, "report.csv").ToHaveMode(t, 0o644)
+}
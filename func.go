@@ -1,6 +1,19 @@
 package expect
 
-import "strings"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rickb777/plural"
+)
 
 // FuncType is used for assertions about functions.
 type FuncType struct {
@@ -42,59 +55,289 @@ func (a FuncType) ToPanic(t Tester) {
 		h.Helper()
 	}
 
+	_, panicked := runCatchingPanic(a.actual) // function under test
+
+	a.toPanic(t, panicked)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToPanicWithMessage asserts that the function did panic.
+// It is not useful to use [FuncType.Not] with this.
+// The substring is used to check that the panic passed a string containing that value.
+// The tester is normally [*testing.B].
+func (a FuncType) ToPanicWithMessage(t Tester, substring string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if a.not {
+		panic("Func().ToPanicWithMessage() does not allow Not() because of ambiguous meaning")
+	}
+
+	recovered, panicked := runCatchingPanic(a.actual) // function under test
+
+	a.toPanicWithMessage(t, substring, recovered, panicked)
+}
+
+//=================================================================================================
+
+func runCatchingPanic(fn func()) (recovered any, panicked bool) {
 	defer func() {
 		if e := recover(); e != nil {
-			if a.not {
-				a.describeActualExpected1("not to panic.\n")
-				a.applyAll(t)
-			} else {
-				a.passes++
-			}
+			recovered = e
+			panicked = true
 		}
 	}()
 
-	a.actual() // function under test
+	fn()
+
+	return nil, false
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a *assertion) toPanic(t Tester, panicked bool) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if !a.not && !panicked {
+		a.describeActualExpected1("to panic.\n")
+	} else if a.not && panicked {
+		a.describeActualExpected1("not to panic.\n")
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a *assertion) toPanicWithMessage(t Tester, substring string, recovered any, panicked bool) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
 
-	if !a.not {
+	if !panicked {
 		a.describeActualExpected1("to panic.\n")
-		a.applyAll(t)
+	} else if s, ok := recovered.(string); !ok {
+		a.describeActualExpected1("to panic with a string containing ―――\n%s\n――― but got %T ―――\n%v\n",
+			substring, recovered, recovered)
+	} else if !strings.Contains(s, substring) {
+		a.describeActualExpected1("to panic with a message containing ―――\n%s\n――― but got ―――\n%s\n",
+			substring, s)
 	} else {
 		a.passes++
 	}
+
+	a.applyAll(t)
 }
 
 //-------------------------------------------------------------------------------------------------
 
-// ToPanicWithMessage asserts that the function did panic.
+// AllocationRuns is the number of times the function is called by [FuncType.ToAllocateAtMost]
+// and [FuncType.ToNotAllocate] in order to find the average allocations per run.
+var AllocationRuns = 100
+
+var nTimes = plural.FromOne("once", "%d times")
+
+// ToNotAllocate asserts that the function does not allocate any memory on the heap.
 // It is not useful to use [FuncType.Not] with this.
-// The substring is used to check that the panic passed a string containing that value.
-// The tester is normally [*testing.B].
-func (a FuncType) ToPanicWithMessage(t Tester, substring string) {
+// The tester is normally [*testing.T].
+func (a FuncType) ToNotAllocate(t Tester) {
 	if h, ok := t.(helper); ok {
 		h.Helper()
 	}
 
 	if a.not {
-		panic("Func().ToPanicWithMessage() does not allow Not() because of ambiguous meaning")
+		panic("Func().ToNotAllocate() does not allow Not() because of ambiguous meaning")
 	}
 
-	defer func() {
-		if e := recover(); e != nil {
-			if s, ok := e.(string); ok {
-				if !strings.Contains(s, substring) {
-					a.describeActualExpected1("to panic with a message containing ―――\n%s\n――― but got ―――\n%s\n",
-						substring, s)
-				}
-			} else {
-				a.describeActualExpected1("to panic with a string containing ―――\n%s\n――― but got %T ―――\n%v\n",
-					substring, e, e)
-			}
-			a.applyAll(t)
+	a.toAllocateAtMost(t, 0)
+}
+
+// ToAllocateAtMost asserts that the function allocates no more than a given number of times
+// per run, on average (see [testing.AllocsPerRun]). Optionally, the average number of bytes
+// allocated per run can also be limited. The limits must not be negative.
+// It is not useful to use [FuncType.Not] with this.
+// The tester is normally [*testing.T].
+func (a FuncType) ToAllocateAtMost(t Tester, allocs int, bytes ...int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if a.not {
+		panic("Func().ToAllocateAtMost() does not allow Not() because of ambiguous meaning")
+	}
+
+	if allocs < 0 || (len(bytes) > 0 && bytes[0] < 0) {
+		panic("Func().ToAllocateAtMost() does not allow negative limits")
+	}
+
+	a.toAllocateAtMost(t, allocs, bytes...)
+}
+
+func (a FuncType) toAllocateAtMost(t Tester, allocs int, bytes ...int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	allocsPerOp := testing.AllocsPerRun(AllocationRuns, a.actual)
+	bytesPerOp := bytesPerRun(AllocationRuns, a.actual)
+
+	if len(bytes) > 0 {
+		if allocsPerOp > float64(allocs) || bytesPerOp > uint64(bytes[0]) {
+			a.describeActualExpected1("to allocate at most %s and %d bytes per run but got ―――\n"+
+				"%v allocs/op, %d bytes/op.\n", nTimes.FormatInt(allocs), bytes[0], allocsPerOp, bytesPerOp)
+		} else {
+			a.passes++
 		}
-	}()
+	} else if allocsPerOp > float64(allocs) {
+		if allocs == 0 {
+			a.describeActualExpected1("not to allocate but got ―――\n"+
+				"%v allocs/op, %d bytes/op.\n", allocsPerOp, bytesPerOp)
+		} else {
+			a.describeActualExpected1("to allocate at most %s per run but got ―――\n"+
+				"%v allocs/op, %d bytes/op.\n", nTimes.FormatInt(allocs), allocsPerOp, bytesPerOp)
+		}
+	} else {
+		a.passes++
+	}
 
-	a.actual() // function under test
+	a.applyAll(t)
+}
+
+// bytesPerRun measures in the same way as testing.AllocsPerRun, but counts the bytes allocated.
+func bytesPerRun(runs int, fn func()) uint64 {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	// warm up the function
+	fn()
+
+	var memstats runtime.MemStats
+	runtime.ReadMemStats(&memstats)
+	before := memstats.TotalAlloc
+
+	for i := 0; i < runs; i++ {
+		fn()
+	}
+
+	runtime.ReadMemStats(&memstats)
+	return (memstats.TotalAlloc - before) / uint64(runs)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ExitOutput holds the output captured by [FuncType.ToExit].
+type ExitOutput struct {
+	stdout, stderr string
+	info           string
+	inert          bool // no output was captured, so assertions about it are skipped
+}
+
+// Stdout returns a string assertion for the standard output of the child process.
+func (o *ExitOutput) Stdout() *StringType[string] {
+	s := String(o.stdout).Info(prefix("stdout of ", o.info))
+	s.inert = o.inert
+	return s
+}
+
+// Stderr returns a string assertion for the standard error of the child process.
+func (o *ExitOutput) Stderr() *StringType[string] {
+	s := String(o.stderr).Info(prefix("stderr of ", o.info))
+	s.inert = o.inert
+	return s
+}
+
+//-------------------------------------------------------------------------------------------------
+
+const toExitEnv = "EXPECT_FUNC_TO_EXIT"
+
+type namer interface {
+	Name() string
+}
+
+var toExitCalls = struct {
+	sync.Mutex
+	count map[string]int
+}{count: make(map[string]int)}
+
+// ToExit asserts that the function exits the process with the specified code, typically
+// because it calls [os.Exit] or [log.Fatal]. The current test binary is run again in a
+// child process, restricted to the current test, and the function is called there.
+// The output it wrote to stdout and stderr is returned so that further assertions can be made.
+//
+// Any code in the test before ToExit is also run in the child process. A function that
+// returns normally is treated as exiting with code 0. Where there is no output to return,
+// e.g. in the child process, assertions about the returned output are skipped.
+//
+// The tester must have a Name method, i.e. it is normally [*testing.T].
+func (a FuncType) ToExit(t Tester, code int) *ExitOutput {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	n, ok := t.(namer)
+	if !ok {
+		t.Fatal(fmt.Sprintf("Func().ToExit() requires a tester with a Name method, such as *testing.T; got %T.\n", t))
+		return &ExitOutput{inert: true}
+	}
+
+	name := n.Name()
+	key := fmt.Sprintf("%s#%d", name, nextToExitCall(name))
+
+	if target, isChild := os.LookupEnv(toExitEnv); isChild {
+		if target == key {
+			a.actual() // function under test
+			os.Exit(0)
+		}
+		return &ExitOutput{inert: true} // this is the child process for a different ToExit call
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(os.Args[0], "-test.run="+testRunPattern(name))
+	cmd.Env = append(os.Environ(), toExitEnv+"="+key)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	actual := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatal(fmt.Sprintf("Func().ToExit() could not run %s ―――\n%v\n", os.Args[0], err))
+			return &ExitOutput{inert: true}
+		}
+		actual = exitErr.ExitCode()
+	}
+
+	output := &ExitOutput{stdout: stdout.String(), stderr: stderr.String(), info: a.info}
+
+	if (!a.not && actual != code) || (a.not && actual == code) {
+		a.describeActualExpected1("%sto exit with code %d but got %d; %s",
+			notS(a.not), code, actual, describeOutput(output.stdout, output.stderr, 0))
+	} else {
+		a.passes++
+	}
 
-	a.describeActualExpected1("to panic.\n")
 	a.applyAll(t)
+	return output
+}
+
+func nextToExitCall(name string) int {
+	toExitCalls.Lock()
+	defer toExitCalls.Unlock()
+	toExitCalls.count[name]++
+	return toExitCalls.count[name]
+}
+
+// testRunPattern converts a test name such as "TestFoo/bar" into a -test.run pattern that
+// matches only that test.
+func testRunPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
 }
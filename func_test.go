@@ -1,6 +1,9 @@
 package expect_test
 
 import (
+	"fmt"
+	"log"
+	"os"
 	"testing"
 
 	"github.com/rickb777/expect"
@@ -19,6 +22,9 @@ func TestFuncToPanic(t *testing.T) {
 func TestFuncToPanicWithMessage(t *testing.T) {
 	c := &capture{}
 
+	expect.Func(func() { panic("ouch!") }).Info("my func").ToPanicWithMessage(c, "ouch")
+	c.shouldNotHaveHadAnError(t)
+
 	expect.Func(func() {}).Info("my func").ToPanicWithMessage(c, "bang")
 	c.shouldHaveCalledErrorf(t, "Expected my func to panic.\n")
 
@@ -60,3 +66,95 @@ func ExampleFuncType_ToPanicWithMessage() {
 
 	expect.Func(func() { panic("boo") }).ToPanicWithMessage(t, "boo")
 }
+
+var sink []byte
+
+func TestFuncToAllocateAtMost(t *testing.T) {
+	c := &capture{}
+
+	expect.Func(func() {}).Info("my func").ToNotAllocate(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Func(func() { sink = make([]byte, 64) }).Info("my func").ToAllocateAtMost(c, 1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Func(func() { sink = make([]byte, 64) }).Info("my func").ToAllocateAtMost(c, 1, 64)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Func(func() { sink = make([]byte, 64) }).Info("my func").ToNotAllocate(c)
+	c.shouldHaveCalledErrorf(t, "Expected my func not to allocate but got ―――\n1 allocs/op, 64 bytes/op.\n")
+
+	expect.Func(func() { sink = make([]byte, 64); sink = make([]byte, 64) }).Info("my func").ToAllocateAtMost(c, 1)
+	c.shouldHaveCalledErrorf(t, "Expected my func to allocate at most once per run but got ―――\n2 allocs/op, 128 bytes/op.\n")
+
+	expect.Func(func() { sink = make([]byte, 64) }).Info("my func").ToAllocateAtMost(c, 1, 32)
+	c.shouldHaveCalledErrorf(t, "Expected my func to allocate at most once and 32 bytes per run but got ―――\n1 allocs/op, 64 bytes/op.\n")
+
+	expect.Func(func() { expect.Func(func() {}).Not().ToNotAllocate(c) }).ToPanicWithMessage(t, "Func().ToNotAllocate() does not allow Not()")
+
+	expect.Func(func() { expect.Func(func() {}).ToAllocateAtMost(c, -1) }).ToPanicWithMessage(t, "does not allow negative limits")
+
+	expect.Func(func() { expect.Func(func() {}).ToAllocateAtMost(c, 1, -1) }).ToPanicWithMessage(t, "does not allow negative limits")
+}
+
+func ExampleFuncType_ToNotAllocate() {
+	var t *testing.T
+
+	buf := make([]byte, 0, 100)
+
+	expect.Func(func() { buf = append(buf[:0], "hello"...) }).ToNotAllocate(t)
+
+	expect.Func(func() { buf = make([]byte, 100) }).ToAllocateAtMost(t, 1, 128)
+}
+
+type namedCapture struct {
+	*capture
+	name string
+}
+
+func (c namedCapture) Name() string { return c.name }
+
+func TestFuncToExit(t *testing.T) {
+	c := namedCapture{capture: &capture{}, name: t.Name()}
+
+	expect.Func(func() {
+		fmt.Println("hello")
+		os.Exit(3)
+	}).ToExit(t, 3).Stdout().ToBe(t, "hello\n")
+
+	expect.Func(func() { log.Fatal("bang") }).ToExit(t, 1).Stderr().ToContain(t, "bang")
+
+	expect.Func(func() {}).ToExit(t, 0).Stdout().ToBeEmpty(t)
+
+	expect.Func(func() { os.Exit(2) }).Not().ToExit(t, 0)
+
+	expect.Func(func() {
+		fmt.Println("hello")
+		os.Exit(3)
+	}).Info("my func").ToExit(c, 4)
+	c.shouldHaveCalledErrorf(t, "Expected my func to exit with code 4 but got 3; stdout ―――\nhello\n――― stderr ―――\n\"\"\n")
+}
+
+func TestFuncToExitSubtest(t *testing.T) {
+	t.Run("a b", func(t *testing.T) {
+		expect.Func(func() { os.Exit(5) }).ToExit(t, 5)
+	})
+}
+
+func TestFuncToExitWithoutName(t *testing.T) {
+	c := &capture{}
+
+	out := expect.Func(func() { os.Exit(1) }).ToExit(c, 1)
+	c.shouldHaveCalledFatalf(t, "Func().ToExit() requires a tester with a Name method, such as *testing.T; got *expect_test.capture.\n")
+	expect.Value(out).Not().ToBeNil(t)
+
+	out.Stdout().ToBe(c, "hello")
+	out.Stderr().ToContain(c, "bang")
+	c.shouldNotHaveHadAnError(t)
+}
+
+func ExampleFuncType_ToExit() {
+	var t *testing.T
+
+	expect.Func(func() { log.Fatal("failed") }).ToExit(t, 1).Stderr().ToContain(t, "failed")
+}
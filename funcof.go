@@ -0,0 +1,113 @@
+package expect
+
+import "fmt"
+
+// FuncOfType is used for assertions about functions that return a result and an error.
+type FuncOfType[T any] struct {
+	result    T
+	err       error
+	recovered any
+	panicked  bool
+	assertion
+}
+
+// FuncOf calls a function once, recording whether it panicked, its result and its error.
+// Assertions can then be made about the panic (see [FuncOfType.ToPanic]) and the outcome
+// can be passed on to other categories (see [FuncOfType.Value], [FuncOfType.Error] and
+// [FuncOfType.Result]).
+func FuncOf[T any](value func() (T, error)) FuncOfType[T] {
+	a := FuncOfType[T]{}
+	a.recovered, a.panicked = runCatchingPanic(func() {
+		a.result, a.err = value() // function under test
+	})
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a FuncOfType[T]) Info(info any, other ...any) FuncOfType[T] {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a FuncOfType[T]) I(info any, other ...any) FuncOfType[T] {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a FuncOfType[T]) Not() FuncOfType[T] {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToPanic asserts that the function did / did not panic.
+// The tester is normally [*testing.T].
+func (a FuncOfType[T]) ToPanic(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toPanic(t, a.panicked)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToPanicWithMessage asserts that the function did panic.
+// It is not useful to use [FuncOfType.Not] with this.
+// The substring is used to check that the panic passed a string containing that value.
+// The tester is normally [*testing.T].
+func (a FuncOfType[T]) ToPanicWithMessage(t Tester, substring string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if a.not {
+		panic("FuncOf().ToPanicWithMessage() does not allow Not() because of ambiguous meaning")
+	}
+
+	a.toPanicWithMessage(t, substring, a.recovered, a.panicked)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Result returns the function's result and its error. If the function panicked, the error
+// describes the panic instead. This allows the outcome to be passed straight in to the other
+// categories, which will then fail if there was an error or a panic, e.g.
+//
+//	expect.Number(expect.FuncOf(fn).Result()).ToBe(t, 42)
+//	expect.String(expect.FuncOf(fn).Result()).ToContain(t, "foo")
+//	expect.Slice(expect.FuncOf(fn).Result()).ToHaveLength(t, 3)
+func (a FuncOfType[T]) Result() (T, error) {
+	if a.panicked {
+		return a.result, panicError{recovered: a.recovered}
+	}
+	return a.result, a.err
+}
+
+// Value passes the function's result to a [Value] assertion, which will fail if
+// the function returned an error or panicked.
+func (a FuncOfType[T]) Value() AnyType[T] {
+	return Value(a.Result()).Info(a.info)
+}
+
+// Error passes the function's error to an [Error] assertion. If the function panicked,
+// the panic is treated as an error.
+func (a FuncOfType[T]) Error() ErrorType {
+	_, err := a.Result()
+	return Error(err).Info(a.info)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+type panicError struct {
+	recovered any
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.recovered)
+}
@@ -0,0 +1,97 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func TestFuncOfToPanic(t *testing.T) {
+	c := &capture{}
+
+	expect.FuncOf(func() (int, error) { panic("ouch") }).Info("my func").ToPanic(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { return 1, nil }).Info("my func").ToPanic(c)
+	c.shouldHaveCalledErrorf(t, "Expected my func to panic.\n")
+
+	expect.FuncOf(func() (int, error) { return 1, nil }).Info("my func").Not().ToPanic(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { panic("ouch") }).Info("my func").Not().ToPanic(c)
+	c.shouldHaveCalledErrorf(t, "Expected my func not to panic.\n")
+}
+
+func TestFuncOfToPanicWithMessage(t *testing.T) {
+	c := &capture{}
+
+	expect.FuncOf(func() (int, error) { panic("ouch!") }).Info("my func").ToPanicWithMessage(c, "ouch")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { return 1, nil }).Info("my func").ToPanicWithMessage(c, "bang")
+	c.shouldHaveCalledErrorf(t, "Expected my func to panic.\n")
+
+	expect.FuncOf(func() (int, error) { panic("happy") }).Info("my func").ToPanicWithMessage(c, "ouch")
+	c.shouldHaveCalledErrorf(t, `Expected my func to panic with a message containing ―――
+ouch
+――― but got ―――
+happy
+`)
+}
+
+func TestFuncOfValue(t *testing.T) {
+	c := &capture{}
+
+	expect.FuncOf(func() (int, error) { return 42, nil }).Value().ToBe(c, 42)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { return 42, nil }).I("answer").Value().ToBe(c, 41)
+	c.shouldHaveCalledErrorf(t, "Expected answer int ―――\n42\n――― to be ―――\n41\n")
+
+	expect.FuncOf(func() (int, error) { return 0, e1 }).I("answer").Value().ToBe(c, 0)
+	c.shouldHaveCalledFatalf(t, "Expected answer not to pass a non-nil error but got error parameter 2 ―――\nsomething bad happened\n")
+
+	expect.FuncOf(func() (int, error) { panic("ouch") }).I("answer").Value().ToBe(c, 0)
+	c.shouldHaveCalledFatalf(t, "Expected answer not to pass a non-nil error but got error parameter 2 ―――\npanic: ouch\n")
+}
+
+func TestFuncOfError(t *testing.T) {
+	c := &capture{}
+
+	expect.FuncOf(func() (int, error) { return 42, nil }).Error().ToBeNil(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { return 0, e1 }).Error().ToWrap(c, e1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.FuncOf(func() (int, error) { panic("ouch") }).I("answer").Error().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected answer error ―――\npanic: ouch\n――― not to have occurred.\n")
+}
+
+func TestFuncOfResult(t *testing.T) {
+	c := &capture{}
+
+	expect.Number(expect.FuncOf(func() (int, error) { return 42, nil }).Result()).ToBe(c, 42)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.String(expect.FuncOf(func() (string, error) { return "foo", nil }).Result()).ToBe(c, "foo")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Slice(expect.FuncOf(func() ([]int, error) { return []int{1, 2}, nil }).Result()).ToBe(c, 1, 2)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Slice(expect.FuncOf(func() ([]int, error) { panic("ouch") }).Result()).ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected not to pass a non-nil error but got error parameter 2 ―――\npanic: ouch\n")
+}
+
+func ExampleFuncOf() {
+	var t *testing.T
+
+	f := expect.FuncOf(func() (int, error) { return 42, nil })
+	f.Not().ToPanic(t)
+	f.Value().ToBe(t, 42)
+	f.Error().ToBeNil(t)
+
+	// the result can be passed to other categories too
+	expect.Number(f.Result()).ToBeGreaterThan(t, 40)
+}
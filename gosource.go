@@ -0,0 +1,285 @@
+package expect
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"slices"
+	"strings"
+)
+
+// GoSourceType is used for assertions about Go source code, such as the output of code generators.
+type GoSourceType struct {
+	actual         []byte
+	ignoreComments bool
+	assertion
+}
+
+// GoSource creates an assertion about the Go source code of a file. The source is a []byte,
+// a string or an [io.Reader].
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func GoSource(src any, other ...any) GoSourceType {
+	a := GoSourceType{assertion: assertion{otherActual: other}}
+
+	rdr, err := readerOf(src)
+	if err == nil {
+		a.actual, err = io.ReadAll(rdr)
+	}
+	if err != nil {
+		a.fault = err.Error()
+	}
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a GoSourceType) Info(info any, other ...any) GoSourceType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a GoSourceType) I(info any, other ...any) GoSourceType {
+	return a.Info(info, other...)
+}
+
+// IgnoreComments causes [GoSourceType.ToBeEquivalentTo] to disregard comments, and also
+// the blank lines that usually surround them.
+func (a GoSourceType) IgnoreComments() GoSourceType {
+	a.ignoreComments = true
+	return a
+}
+
+// Not inverts the assertion.
+func (a GoSourceType) Not() GoSourceType {
+	a.not = !a.not
+	return a
+}
+
+func parseGoSource(src []byte, mode parser.Mode) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, mode|parser.AllErrors)
+	return fset, f, err
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeValid asserts that the source code can be parsed. It is not type-checked.
+// The tester is normally [*testing.T].
+func (a GoSourceType) ToBeValid(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	_, _, err := parseGoSource(a.actual, parser.ParseComments)
+
+	if !a.not && err != nil {
+		a.describeActualExpected1("Go source to be valid but ―――\n%v\n", err)
+	} else if a.not && err == nil {
+		a.describeActualExpected1("Go source not to be valid but it parsed successfully.\n")
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeFormatted asserts that the source code is valid and is exactly as [format.Source]
+// would format it, including its comments, whether or not [GoSourceType.IgnoreComments] is used.
+// Failures show the lines that differ.
+// The tester is normally [*testing.T].
+func (a GoSourceType) ToBeFormatted(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	formatted, err := normaliseGoSource(a.actual, false)
+	if err != nil && a.fault == "" {
+		a.fault = fmt.Sprintf("it is not valid Go: %v", err)
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	same := formatted == string(a.actual)
+	diffs := diffLines(splitLines(formatted), splitLines(string(a.actual)))
+
+	if !a.not && !same && len(diffs) == 0 {
+		a.describeActualExpected1("Go source to be formatted but %s.\n", finalNewlineDiff(formatted, string(a.actual)))
+	} else if !a.not && !same {
+		a.describeActualExpected1("Go source to be formatted but %s (-gofmt, +got) ―――\n%s",
+			thereWereNDifferingLines.FormatInt(countChangedLines(diffs)), strings.Join(diffs, ""))
+	} else if a.not && same {
+		a.describeActualExpected1("Go source not to be formatted but it was.\n")
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeEquivalentTo asserts that the source code is the same as the expected source code after
+// both have been formatted, optionally ignoring comments (see [GoSourceType.IgnoreComments]).
+// The expected source is a []byte, a string or an [io.Reader]. Failures show the lines that differ.
+// The tester is normally [*testing.T].
+func (a GoSourceType) ToBeEquivalentTo(t Tester, expected any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	actual, err := normaliseGoSource(a.actual, a.ignoreComments)
+	if err != nil && a.fault == "" {
+		a.fault = fmt.Sprintf("it is not valid Go: %v", err)
+	}
+
+	var exSrc []byte
+	rdr, err := readerOf(expected)
+	if err == nil {
+		exSrc, err = io.ReadAll(rdr)
+	}
+	if err != nil && a.fault == "" {
+		a.fault = fmt.Sprintf("the expected source could not be read: %v", err)
+	}
+
+	exNorm, err := normaliseGoSource(exSrc, a.ignoreComments)
+	if err != nil && a.fault == "" {
+		a.fault = fmt.Sprintf("the expected source is not valid Go: %v", err)
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	diffs := diffLines(splitLines(exNorm), splitLines(actual))
+
+	if !a.not && len(diffs) > 0 {
+		a.describeActualExpected1("Go source to be equivalent but %s (-want, +got) ―――\n%s",
+			thereWereNDifferingLines.FormatInt(countChangedLines(diffs)), strings.Join(diffs, ""))
+	} else if a.not && len(diffs) == 0 {
+		a.describeActualExpected1("Go source not to be equivalent but it was.\n")
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func normaliseGoSource(src []byte, ignoreComments bool) (string, error) {
+	mode := parser.ParseComments
+	if ignoreComments {
+		mode = 0
+	}
+
+	fset, f, err := parseGoSource(src, mode)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, f); err != nil {
+		return "", err
+	}
+
+	if !ignoreComments {
+		return buf.String(), nil
+	}
+
+	lines := slices.DeleteFunc(splitLines(buf.String()), func(s string) bool { return s == "" })
+	return strings.Join(lines, "\n"), nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToDeclare asserts that the source code declares something at package level. The declaration is
+// written as a keyword followed by a name, for example "func NewServer", "type Server", "var Debug"
+// or "const Version". Methods are written with their receiver type, e.g. "func Server.Start".
+// The tester is normally [*testing.T].
+func (a GoSourceType) ToDeclare(t Tester, declaration string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	_, f, err := parseGoSource(a.actual, 0)
+	if err != nil && a.fault == "" {
+		a.fault = fmt.Sprintf("it is not valid Go: %v", err)
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	decls := declarations(f)
+	found := slices.Contains(decls, strings.Join(strings.Fields(declaration), " "))
+
+	if !a.not && !found {
+		if len(decls) == 0 {
+			a.describeActualExpected1("Go source to declare %s but it has no declarations.\n", declaration)
+		} else {
+			a.describeActualExpectedM("Go source declaring ―――\n%s\n", strings.Join(decls, "\n"))
+			a.addExpectation("to declare %s.\n", declaration)
+		}
+	} else if a.not && found {
+		a.describeActualExpected1("Go source not to declare %s.\n", declaration)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func declarations(f *ast.File) []string {
+	if f == nil {
+		return nil
+	}
+
+	var decls []string
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			name := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				name = receiverTypeName(d.Recv.List[0].Type) + "." + name
+			}
+			decls = append(decls, "func "+name)
+
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				switch s := s.(type) {
+				case *ast.TypeSpec:
+					decls = append(decls, "type "+s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						decls = append(decls, d.Tok.String()+" "+n.Name)
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(e.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return "?"
+}
+
+//-------------------------------------------------------------------------------------------------
@@ -0,0 +1,193 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+const generated = `package demo
+
+// Server serves things.
+type Server struct {
+	Addr string
+}
+
+// NewServer makes a server.
+func NewServer(addr string) *Server {
+	return &Server{Addr: addr}
+}
+
+func (s *Server) Start() error {
+	return nil
+}
+
+var Debug, Verbose bool
+
+const Version = "1.0"
+`
+
+func TestGoSourceToBeValid(t *testing.T) {
+	c := &capture{}
+
+	expect.GoSource(generated).ToBeValid(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource("package demo\n\nfunc X( {\n").I("gen").ToBeValid(c)
+	c.shouldHaveCalledErrorf(t, "Expected gen Go source to be valid but ―――\n3:9: expected ')', found '{' (and 3 more errors)\n")
+
+	expect.GoSource([]byte(generated)).Not().ToBeValid(c)
+	c.shouldHaveCalledErrorf(t, "Expected Go source not to be valid but it parsed successfully.\n")
+}
+
+func TestGoSourceToBeFormatted(t *testing.T) {
+	c := &capture{}
+
+	expect.GoSource(generated).ToBeFormatted(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource("package demo\n\nfunc X()  {\n  return\n}\n").ToBeFormatted(c)
+	c.shouldHaveCalledErrorf(t, `Expected Go source to be formatted but there were 4 differing lines (-gofmt, +got) ―――
+@@ line 1 @@
+  package demo
+  
+- func X() {
+- 	return
++ func X()  {
++   return
+  }
+`)
+
+	expect.GoSource(generated).IgnoreComments().ToBeFormatted(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource("package demo\n\nfunc X() {}").ToBeFormatted(c)
+	c.shouldHaveCalledErrorf(t, "Expected Go source to be formatted but the final newline is missing.\n")
+
+	expect.GoSource("package demo\n\nfunc X( {\n").Not().ToBeFormatted(c)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but it is not valid Go: 3:9: expected ')', found '{' (and 3 more errors).\n")
+}
+
+func TestGoSourceToBeEquivalentTo(t *testing.T) {
+	c := &capture{}
+
+	expect.GoSource(generated).ToBeEquivalentTo(c, generated)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource(generated).IgnoreComments().ToBeEquivalentTo(c, `package demo
+type Server struct { Addr string }
+func NewServer(addr string) *Server { return &Server{Addr: addr} }
+func (s *Server) Start() error { return nil }
+var Debug, Verbose bool
+const Version = "1.0"
+`)
+	c.shouldHaveCalledErrorf(t, `Expected Go source to be equivalent but there were 12 differing lines (-want, +got) ―――
+@@ line 1 @@
+  package demo
+- type Server struct{ Addr string }
+- func NewServer(addr string) *Server { return &Server{Addr: addr} }
+- func (s *Server) Start() error      { return nil }
++ type Server struct {
++ 	Addr string
++ }
++ func NewServer(addr string) *Server {
++ 	return &Server{Addr: addr}
++ }
++ func (s *Server) Start() error {
++ 	return nil
++ }
+  var Debug, Verbose bool
+  const Version = "1.0"
+`)
+
+	expect.GoSource(generated).IgnoreComments().ToBeEquivalentTo(c, `package demo
+
+type Server struct {
+	Addr string
+}
+
+func NewServer(addr string) *Server {
+	return &Server{Addr: addr}
+}
+
+func (s *Server) Start() error {
+	return nil
+}
+
+var Debug, Verbose bool
+
+const Version = "1.0"
+`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource(generated).I("server.go").ToBeEquivalentTo(c, `package demo
+
+// Server serves things.
+type Server struct {
+	Addr string
+}
+
+// NewServer makes a server.
+func NewServer(addr string) *Server {
+	return &Server{Addr: addr}
+}
+
+func (s *Server) Start() error {
+	return errors.New("not yet")
+}
+
+var Debug, Verbose bool
+
+const Version = "1.0"
+`)
+	c.shouldHaveCalledErrorf(t, `Expected server.go Go source to be equivalent but there were 2 differing lines (-want, +got) ―――
+@@ line 12 @@
+  
+  func (s *Server) Start() error {
+- 	return errors.New("not yet")
++ 	return nil
+  }
+  
+`)
+}
+
+func TestGoSourceToDeclare(t *testing.T) {
+	c := &capture{}
+
+	for _, decl := range []string{"type Server", "func NewServer", "func Server.Start", "var Verbose", "const Version"} {
+		expect.GoSource(generated).ToDeclare(c, decl)
+		c.shouldNotHaveHadAnError(t)
+	}
+
+	expect.GoSource(generated).Not().ToDeclare(c, "func Start")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.GoSource(generated).ToDeclare(c, "func Server.Stop")
+	c.shouldHaveCalledErrorf(t, `Expected Go source declaring ―――
+type Server
+func NewServer
+func Server.Start
+var Debug
+var Verbose
+const Version
+――― to declare func Server.Stop.
+`)
+
+	expect.GoSource("package demo\n").ToDeclare(c, "type Server")
+	c.shouldHaveCalledErrorf(t, "Expected Go source to declare type Server but it has no declarations.\n")
+
+	expect.GoSource(generated).Not().ToDeclare(c, "type Server")
+	c.shouldHaveCalledErrorf(t, "Expected Go source not to declare type Server.\n")
+}
+
+func ExampleGoSource() {
+	var t *testing.T
+
+	var generated []byte // ... output from a code generator
+
+	expect.GoSource(generated).ToBeFormatted(t)
+	expect.GoSource(generated).ToDeclare(t, "func NewServer")
+	expect.GoSource(generated).IgnoreComments().ToBeEquivalentTo(t, `package demo
+func NewServer() *Server { return &Server{} }
+`)
+}
@@ -0,0 +1,154 @@
+package expect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// HandlerType is used to drive an [http.Handler] in-process, so that assertions can be made
+// about its responses.
+type HandlerType struct {
+	handler http.Handler
+	info    string
+}
+
+// Handler creates a test driver for an [http.Handler]. Requests are built fluently and served
+// in-process using [httptest], so no network sockets are used. For example
+//
+//	expect.Handler(h).GET("/fruit").WithHeader("Accept", "application/json").Response().ToHaveStatus(t, 200)
+func Handler(handler http.Handler) HandlerType {
+	return HandlerType{handler: handler}
+}
+
+// Info adds a description of the handler to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (h HandlerType) Info(info any, other ...any) HandlerType {
+	h.info = makeInfo(info, other...)
+	return h
+}
+
+// I is a synonym for [Info].
+func (h HandlerType) I(info any, other ...any) HandlerType {
+	return h.Info(info, other...)
+}
+
+// GET starts building a GET request for the target, which is a path with an optional query.
+func (h HandlerType) GET(target string) HandlerRequest {
+	return h.Request(http.MethodGet, target)
+}
+
+// HEAD starts building a HEAD request for the target, which is a path with an optional query.
+func (h HandlerType) HEAD(target string) HandlerRequest {
+	return h.Request(http.MethodHead, target)
+}
+
+// POST starts building a POST request for the target, which is a path with an optional query.
+func (h HandlerType) POST(target string) HandlerRequest {
+	return h.Request(http.MethodPost, target)
+}
+
+// PUT starts building a PUT request for the target, which is a path with an optional query.
+func (h HandlerType) PUT(target string) HandlerRequest {
+	return h.Request(http.MethodPut, target)
+}
+
+// PATCH starts building a PATCH request for the target, which is a path with an optional query.
+func (h HandlerType) PATCH(target string) HandlerRequest {
+	return h.Request(http.MethodPatch, target)
+}
+
+// DELETE starts building a DELETE request for the target, which is a path with an optional query.
+func (h HandlerType) DELETE(target string) HandlerRequest {
+	return h.Request(http.MethodDelete, target)
+}
+
+// Request starts building a request with any method for the target, which is a path with an
+// optional query.
+func (h HandlerType) Request(method, target string) HandlerRequest {
+	return HandlerRequest{handler: h.handler, info: h.info, method: method, target: target, header: http.Header{}}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// HandlerRequest is a request being built for a [HandlerType].
+type HandlerRequest struct {
+	handler http.Handler
+	info    string
+	method  string
+	target  string
+	header  http.Header
+	body    []byte
+	fault   string
+}
+
+// WithHeader adds a request header. It can be used repeatedly, including for the same name.
+func (r HandlerRequest) WithHeader(name, value string) HandlerRequest {
+	r.header = r.header.Clone()
+	r.header.Add(name, value)
+	return r
+}
+
+// WithBody sets the request body, which can be a []byte, a string or an [io.Reader].
+func (r HandlerRequest) WithBody(body any) HandlerRequest {
+	rdr, err := readerOf(body)
+	if err == nil {
+		r.body, err = io.ReadAll(rdr)
+	}
+	if err != nil {
+		r.fault = fmt.Sprintf("the request body could not be read: %v", err)
+	}
+	return r
+}
+
+// Request returns the [*http.Request] that will be passed to the handler. A target that is
+// just a path is sent to host example.com, as with [httptest.NewRequest]. An error is returned
+// if the method or target is invalid.
+func (r HandlerRequest) Request() (*http.Request, error) {
+	target := r.target
+	if strings.HasPrefix(target, "/") {
+		target = "http://example.com" + target
+	}
+
+	req, err := http.NewRequest(r.method, target, bytes.NewReader(r.body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.RequestURI = r.target
+	req.RemoteAddr = "192.0.2.1:1234"
+	for name, values := range r.header {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	return req, nil
+}
+
+// Response serves the request using the handler and creates a [Response] assertion for the
+// result. The assertion is described by the request method and target, plus any info given
+// to the [HandlerType]. Each call serves the request again.
+func (r HandlerRequest) Response() ResponseType {
+	info := r.method + " " + r.target
+	if r.info != "" {
+		info = r.info + " " + info
+	}
+
+	req, err := r.Request()
+	if err != nil && r.fault == "" {
+		r.fault = fmt.Sprintf("the request could not be created: %v", err)
+	}
+
+	if r.fault != "" {
+		a := Response(&http.Response{Header: http.Header{}}).Info(info)
+		a.fault = r.fault
+		return a
+	}
+
+	w := httptest.NewRecorder()
+	r.handler.ServeHTTP(w, req)
+	return Response(w).Info(info)
+}
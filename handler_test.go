@@ -0,0 +1,108 @@
+package expect_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/echo":
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Query", r.URL.RawQuery)
+		w.Header()["X-Tags"] = r.Header.Values("X-Tag")
+		_, _ = w.Write(body)
+	default:
+		http.NotFound(w, r)
+	}
+})
+
+func TestHandlerGET(t *testing.T) {
+	c := &capture{}
+
+	expect.Handler(echoHandler).GET("/echo?a=1").Response().ToHaveStatus(c, http.StatusOK)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Handler(echoHandler).GET("/echo?a=1").Response().ToHaveHeader(c, "X-Query", "a=1")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Handler(echoHandler).GET("/missing").Response().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledErrorf(t, `Expected GET /missing response ―――
+HTTP/1.1 404 Not Found
+Content-Type: text/plain; charset=utf-8
+X-Content-Type-Options: nosniff
+
+404 page not found
+
+――― to have status 200 OK.
+`)
+
+	expect.Handler(echoHandler).I("echo").GET("/missing").Response().Not().ToHaveStatus(c, http.StatusNotFound)
+	c.shouldHaveCalledErrorf(t, `Expected echo GET /missing response ―――
+HTTP/1.1 404 Not Found
+Content-Type: text/plain; charset=utf-8
+X-Content-Type-Options: nosniff
+
+404 page not found
+
+――― not to have status 404 Not Found.
+`)
+}
+
+func TestHandlerPOST(t *testing.T) {
+	c := &capture{}
+
+	post := expect.Handler(echoHandler).POST("/echo").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("X-Tag", "a").
+		WithHeader("X-Tag", "b").
+		WithBody(`{"name":"apple"}`)
+
+	post.Response().ToHaveContentType(c, "application/json")
+	c.shouldNotHaveHadAnError(t)
+
+	post.Response().ToHaveHeader(c, "X-Tags", "b")
+	c.shouldNotHaveHadAnError(t)
+
+	post.Response().JSON().At("name").String().ToBe(c, "apple")
+	c.shouldNotHaveHadAnError(t)
+
+	// the request can be served repeatedly and the body is sent each time
+	post.Response().Body().ToBe(c, `{"name":"apple"}`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Handler(echoHandler).PUT("/echo").WithBody([]byte("pear")).Response().Body().ToBe(c, "pear")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Handler(echoHandler).Request("OPTIONS", "/echo").Response().ToHaveHeader(c, "X-Method", "OPTIONS")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Handler(echoHandler).PATCH("/echo").WithBody(42).Response().Not().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledFatalf(t, "Expected PATCH /echo to be usable but the request body could not be read: int is not []byte, string or io.Reader.\n")
+
+	expect.Handler(echoHandler).Request("BAD METHOD", "/echo").Response().Not().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledFatalf(t, "Expected BAD METHOD /echo to be usable but the request could not be created: net/http: invalid method \"BAD METHOD\".\n")
+
+	expect.Handler(echoHandler).GET("/echo%zz").Response().Not().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledFatalf(t, "Expected GET /echo%zz to be usable but the request could not be created: parse \"http://example.com/echo%zz\": invalid URL escape \"%zz\".\n")
+}
+
+func ExampleHandler() {
+	var t *testing.T
+
+	var myHandler http.Handler // the handler under test
+
+	expect.Handler(myHandler).GET("/fruit/apple").
+		WithHeader("Accept", "application/json").
+		Response().ToHaveStatus(t, http.StatusOK)
+
+	expect.Handler(myHandler).POST("/fruit").
+		WithHeader("Content-Type", "application/json").
+		WithBody(`{"name":"pear"}`).
+		Response().JSON().At("id").Number().ToBeGreaterThan(t, 0)
+}
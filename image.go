@@ -0,0 +1,240 @@
+package expect
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/rickb777/plural"
+)
+
+// ImageType is used for assertions about images.
+type ImageType struct {
+	actual   image.Image
+	diffFile string
+	assertion
+}
+
+// Image creates an assertion about an image.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Image(value image.Image, other ...any) ImageType {
+	a := ImageType{actual: value, assertion: assertion{otherActual: other}}
+	if isNilish(value) {
+		a.fault = "the image is nil"
+		a.actual = image.NewNRGBA(image.Rectangle{})
+	}
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a ImageType) Info(info any, other ...any) ImageType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a ImageType) I(info any, other ...any) ImageType {
+	return a.Info(info, other...)
+}
+
+// WriteDiff causes [ImageType.ToBeSimilarTo] to write a PNG file highlighting the differing
+// pixels in red if the assertion fails. The filename is typically in testdata, for example
+// "testdata/chart.diff.png"; its directory is created if necessary.
+func (a ImageType) WriteDiff(filename string) ImageType {
+	a.diffFile = filename
+	return a
+}
+
+// Not inverts the assertion.
+func (a ImageType) Not() ImageType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveBounds asserts that the image has the expected bounds.
+// The tester is normally [*testing.T].
+func (a ImageType) ToHaveBounds(t Tester, expected image.Rectangle) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual := a.actual.Bounds()
+
+	if !a.not && actual != expected {
+		a.describeActualExpected1("image to have bounds %v but it was %v.\n", expected, actual)
+	} else if a.not && actual == expected {
+		a.describeActualExpected1("image not to have bounds %v.\n", expected)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+var nPixelsDiffer = plural.FromOne("1 pixel differs", "%d pixels differ")
+
+// ToBeSimilarTo asserts that the image has the same bounds as the expected image and that every
+// pixel has a similar colour. The distance between two colours is the Euclidean distance between
+// their red, green, blue and alpha components, scaled so that it is in the range 0 to 1.
+// Pixels are similar when this distance is no more than the tolerance; zero tolerance requires
+// an exact match.
+//
+// Failures describe where the differences are. Also see [ImageType.WriteDiff].
+// The tester is normally [*testing.T].
+func (a ImageType) ToBeSimilarTo(t Tester, expected image.Image, tolerance float64) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if isNilish(expected) {
+		t.Fatal(fmt.Sprintf("Expected%s image to be similar to the expected image but the expected image is nil.\n", preS(a.info)))
+		return
+	}
+
+	size := imageSize(a.actual.Bounds())
+
+	if a.actual.Bounds() != expected.Bounds() {
+		if !a.not {
+			a.describeActualExpected1("image %s to be similar to the expected image but the bounds are %v, not %v.\n",
+				size, a.actual.Bounds(), expected.Bounds())
+		} else {
+			a.passes++
+		}
+		a.applyAll(t)
+		return
+	}
+
+	d := compareImages(a.actual, expected, tolerance)
+
+	if !a.not && d.count > 0 {
+		a.describeActualExpected1("image %s to be similar to the expected image within tolerance %g but %s ―――\n"+
+			"the differences are within %v\n"+
+			"the first is at %v: got %s, want %s, distance %.4f\n"+
+			"the maximum distance is %.4f\n%s",
+			size, tolerance, nPixelsDiffer.FormatInt(d.count),
+			d.region,
+			d.first, rgbaString(a.actual.At(d.first.X, d.first.Y)), rgbaString(expected.At(d.first.X, d.first.Y)), d.firstDistance,
+			d.maxDistance, a.writeDiff(expected, tolerance))
+	} else if a.not && d.count == 0 {
+		a.describeActualExpected1("image %s not to be similar to the expected image within tolerance %g but all pixels are within %.4f.\n",
+			size, tolerance, d.maxDistance)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+// writeDiff writes the diff image, if required, and describes the outcome.
+func (a ImageType) writeDiff(expected image.Image, tolerance float64) string {
+	if a.diffFile == "" {
+		return ""
+	}
+
+	err := os.MkdirAll(filepath.Dir(a.diffFile), 0o755)
+	if err == nil {
+		var f *os.File
+		f, err = os.Create(a.diffFile)
+		if err == nil {
+			err = png.Encode(f, diffImage(a.actual, expected, tolerance))
+			if e2 := f.Close(); err == nil {
+				err = e2
+			}
+		}
+	}
+
+	if err != nil {
+		return fmt.Sprintf("the diff image could not be written: %v\n", err)
+	}
+	return fmt.Sprintf("the differences are shown in %s\n", a.diffFile)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+type imageDiff struct {
+	count         int
+	first         image.Point
+	firstDistance float64
+	maxDistance   float64
+	region        image.Rectangle
+}
+
+func compareImages(actual, expected image.Image, tolerance float64) imageDiff {
+	var d imageDiff
+	b := actual.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dist := colourDistance(actual.At(x, y), expected.At(x, y))
+			d.maxDistance = max(d.maxDistance, dist)
+			if dist > tolerance {
+				p := image.Pt(x, y)
+				if d.count == 0 {
+					d.first = p
+					d.firstDistance = dist
+					d.region = image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))}
+				} else {
+					d.region = d.region.Union(image.Rectangle{Min: p, Max: p.Add(image.Pt(1, 1))})
+				}
+				d.count++
+			}
+		}
+	}
+	return d
+}
+
+// colourDistance is the Euclidean distance between two colours in RGBA space, scaled to 0..1.
+func colourDistance(c1, c2 color.Color) float64 {
+	r1, g1, b1, a1 := c1.RGBA()
+	r2, g2, b2, a2 := c2.RGBA()
+	dr := (float64(r1) - float64(r2)) / 0xffff
+	dg := (float64(g1) - float64(g2)) / 0xffff
+	db := (float64(b1) - float64(b2)) / 0xffff
+	da := (float64(a1) - float64(a2)) / 0xffff
+	return math.Sqrt(dr*dr+dg*dg+db*db+da*da) / 2
+}
+
+// diffImage shows the expected image faded to grey, with the differing pixels in red.
+func diffImage(actual, expected image.Image, tolerance float64) image.Image {
+	b := expected.Bounds()
+	img := image.NewNRGBA(b)
+	red := color.NRGBA{R: 255, A: 255}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if colourDistance(actual.At(x, y), expected.At(x, y)) > tolerance {
+				img.Set(x, y, red)
+			} else {
+				g := color.GrayModel.Convert(expected.At(x, y)).(color.Gray)
+				faded := 191 + g.Y/4
+				img.Set(x, y, color.NRGBA{R: faded, G: faded, B: faded, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func rgbaString(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("rgba(%d,%d,%d,%d)", n.R, n.G, n.B, n.A)
+}
+
+func imageSize(r image.Rectangle) string {
+	return fmt.Sprintf("%d×%d", r.Dx(), r.Dy())
+}
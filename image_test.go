@@ -0,0 +1,123 @@
+package expect_test
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func square(size int, background, foreground color.Color, at image.Rectangle) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if image.Pt(x, y).In(at) {
+				img.Set(x, y, foreground)
+			} else {
+				img.Set(x, y, background)
+			}
+		}
+	}
+	return img
+}
+
+var (
+	white = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	blue  = color.NRGBA{B: 255, A: 255}
+	navy  = color.NRGBA{B: 250, A: 255}
+)
+
+func TestImageToHaveBounds(t *testing.T) {
+	c := &capture{}
+
+	img := square(10, white, blue, image.Rect(2, 2, 5, 5))
+
+	expect.Image(img).ToHaveBounds(c, image.Rect(0, 0, 10, 10))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Image(img).I("chart").ToHaveBounds(c, image.Rect(0, 0, 20, 10))
+	c.shouldHaveCalledErrorf(t, "Expected chart image to have bounds (0,0)-(20,10) but it was (0,0)-(10,10).\n")
+
+	expect.Image(img).Not().ToHaveBounds(c, image.Rect(0, 0, 10, 10))
+	c.shouldHaveCalledErrorf(t, "Expected image not to have bounds (0,0)-(10,10).\n")
+
+	expect.Image(nil).Not().ToHaveBounds(c, image.Rect(0, 0, 1, 1))
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the image is nil.\n")
+}
+
+func TestImageToBeSimilarTo(t *testing.T) {
+	c := &capture{}
+
+	img := square(10, white, blue, image.Rect(2, 2, 5, 5))
+
+	expect.Image(img).ToBeSimilarTo(c, square(10, white, blue, image.Rect(2, 2, 5, 5)), 0)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Image(img).ToBeSimilarTo(c, square(10, white, navy, image.Rect(2, 2, 5, 5)), 0.01)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Image(img).Not().ToBeSimilarTo(c, square(10, white, blue, image.Rect(3, 3, 6, 6)), 0.01)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Image(img).I("chart").ToBeSimilarTo(c, square(10, white, navy, image.Rect(3, 2, 6, 5)), 0.001)
+	c.shouldHaveCalledErrorf(t, `Expected chart image 10×10 to be similar to the expected image within tolerance 0.001 but 12 pixels differ ―――
+the differences are within (2,2)-(6,5)
+the first is at (2,2): got rgba(0,0,255,255), want rgba(255,255,255,255), distance 0.7071
+the maximum distance is 0.7072
+`)
+
+	expect.Image(img).ToBeSimilarTo(c, nil, 0.01)
+	c.shouldHaveCalledFatalf(t, "Expected image to be similar to the expected image but the expected image is nil.\n")
+
+	var none *image.NRGBA
+	expect.Image(img).I("chart").Not().ToBeSimilarTo(c, none, 0.01)
+	c.shouldHaveCalledFatalf(t, "Expected chart image to be similar to the expected image but the expected image is nil.\n")
+
+	expect.Image(img).ToBeSimilarTo(c, square(12, white, blue, image.Rect(2, 2, 5, 5)), 0.01)
+	c.shouldHaveCalledErrorf(t, "Expected image 10×10 to be similar to the expected image but the bounds are (0,0)-(10,10), not (0,0)-(12,12).\n")
+
+	expect.Image(img).Not().ToBeSimilarTo(c, square(10, white, navy, image.Rect(2, 2, 5, 5)), 0.01)
+	c.shouldHaveCalledErrorf(t, "Expected image 10×10 not to be similar to the expected image within tolerance 0.01 but all pixels are within 0.0098.\n")
+}
+
+func TestImageWriteDiff(t *testing.T) {
+	c := &capture{}
+
+	diffFile := filepath.Join(t.TempDir(), "testdata", "square.diff.png")
+	img := square(10, white, blue, image.Rect(2, 2, 5, 5))
+
+	expect.Image(img).WriteDiff(diffFile).ToBeSimilarTo(c, square(10, white, blue, image.Rect(2, 2, 5, 6)), 0)
+	c.shouldHaveCalledErrorf(t, `Expected image 10×10 to be similar to the expected image within tolerance 0 but 3 pixels differ ―――
+the differences are within (2,5)-(5,6)
+the first is at (2,5): got rgba(255,255,255,255), want rgba(0,0,255,255), distance 0.7071
+the maximum distance is 0.7071
+the differences are shown in `+diffFile+`
+`)
+
+	f, err := os.Open(diffFile)
+	expect.Error(err).ToBeNil(t)
+	defer f.Close()
+
+	diff, err := png.Decode(f)
+	expect.Image(diff, err).ToHaveBounds(t, image.Rect(0, 0, 10, 10))
+	expect.Any(color.NRGBAModel.Convert(diff.At(3, 5))).ToBe(t, color.NRGBA{R: 255, A: 255})
+	expect.Any(color.NRGBAModel.Convert(diff.At(0, 0))).ToBe(t, color.NRGBA{R: 254, G: 254, B: 254, A: 255})
+}
+
+func ExampleImage() {
+	var t *testing.T
+
+	var chart image.Image // ... rendered by the code under test
+
+	f, err := os.Open("testdata/chart.png")
+	expect.Error(err).ToBeNil(t)
+	defer f.Close()
+
+	expected, err := png.Decode(f)
+	expect.Image(chart).ToHaveBounds(t, image.Rect(0, 0, 640, 480))
+	expect.Image(chart, err).WriteDiff("testdata/chart.diff.png").ToBeSimilarTo(t, expected, 0.02)
+}
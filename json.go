@@ -0,0 +1,363 @@
+package expect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rickb777/plural"
+)
+
+// JSONType is used for assertions about JSON documents.
+type JSONType struct {
+	actual any
+	path   string
+	assertion
+}
+
+// JSON creates an assertion about a JSON document, which is parsed from a []byte, string
+// or [io.Reader] (including subtypes of []byte and string). Documents are compared semantically,
+// i.e. the order of object keys and any insignificant whitespace are ignored, and numbers are
+// compared numerically.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func JSON(data any, other ...any) JSONType {
+	a := JSONType{path: "$", assertion: assertion{otherActual: other}}
+	v, err := parseJSON(data)
+	if err != nil {
+		a.fault = err.Error()
+	}
+	a.actual = v
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a JSONType) Info(info any, other ...any) JSONType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a JSONType) I(info any, other ...any) JSONType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a JSONType) Not() JSONType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func parseJSON(data any) (any, error) {
+	r, err := readerOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("the JSON could not be parsed: %w", err)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("the JSON has unexpected data after the top-level value")
+	}
+
+	return v, nil
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// At selects part of the document using either a JSON Pointer (RFC 6901) such as "/items/2/price"
+// or a simple path such as "items[2].price". Further assertions then apply to that part only.
+// If the path cannot be followed, these assertions will fail.
+func (a JSONType) At(path string) JSONType {
+	if a.fault != "" {
+		return a
+	}
+
+	var steps []any
+	if path == "" || strings.HasPrefix(path, "/") {
+		steps = parseJSONPointer(path)
+	} else {
+		var err error
+		steps, err = parseFieldPath(path)
+		if err != nil {
+			a.fault = err.Error()
+			return a
+		}
+	}
+
+	for _, step := range steps {
+		var fault string
+		a.actual, fault = selectJSON(a.actual, step, a.path)
+		a.path = jsonPath(a.path, step)
+		if fault != "" {
+			a.fault = fault
+			return a
+		}
+	}
+
+	return a
+}
+
+func parseJSONPointer(pointer string) []any {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	steps := make([]any, len(tokens))
+	for i, t := range tokens {
+		steps[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return steps
+}
+
+func selectJSON(v any, step any, path string) (any, string) {
+	key := fmt.Sprint(step)
+
+	switch d := v.(type) {
+	case map[string]any:
+		e, exists := d[key]
+		if !exists {
+			return nil, fmt.Sprintf("%s has no member %q", path, key)
+		}
+		return e, ""
+
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Sprintf("%s is an array, which cannot be indexed by %q", path, key)
+		}
+		if i < 0 || i >= len(d) {
+			return nil, fmt.Sprintf("index %d is out of range for %s with length %d", i, path, len(d))
+		}
+		return d[i], ""
+	}
+
+	return nil, fmt.Sprintf("%s is %s, which has no member %q", path, jsonKind(v), key)
+}
+
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func jsonPath(path string, step any) string {
+	switch s := step.(type) {
+	case int:
+		return fmt.Sprintf("%s[%d]", path, s)
+	default:
+		key := fmt.Sprint(s)
+		if _, err := strconv.Atoi(key); err == nil {
+			return fmt.Sprintf("%s[%s]", path, key)
+		}
+		if jsonIdentifier.MatchString(key) {
+			return path + "." + key
+		}
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeEquivalentTo asserts that the actual and expected JSON documents are semantically the same.
+// The expected document can be a []byte, string or [io.Reader]. When they differ, every difference
+// is listed with its path.
+// The tester is normally [*testing.T].
+func (a JSONType) ToBeEquivalentTo(t Tester, expected any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.info = a.pathInfo()
+	a.allOtherArgumentsMustNotBeError(t)
+
+	ex, err := parseJSON(expected)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Expected%s to be compared with valid JSON but %v.\n", preS(a.info), err))
+		return
+	}
+
+	diffs := diffJSON(nil, a.path, ex, a.actual)
+
+	if !a.not && len(diffs) > 0 {
+		a.describeActualExpected1("to be equivalent but %s ―――\n%s\n",
+			thereWereNDifferences.FormatInt(len(diffs)), strings.Join(diffs, "\n"))
+	} else if a.not && len(diffs) == 0 {
+		a.describeActualExpected1("not to be equivalent to ―――\n%s\n", compactJSON(ex))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+var thereWereNDifferences = plural.FromOne("there was one difference", "there were %d differences")
+
+func diffJSON(diffs []string, path string, expected, actual any) []string {
+	switch ex := expected.(type) {
+	case map[string]any:
+		ac, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(ex, ac) {
+			ev, inExpected := ex[k]
+			av, inActual := ac[k]
+			p := jsonPath(path, k)
+			switch {
+			case !inActual:
+				diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", p, compactJSON(ev)))
+			case !inExpected:
+				diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", p, compactJSON(av)))
+			default:
+				diffs = diffJSON(diffs, p, ev, av)
+			}
+		}
+		return diffs
+
+	case []any:
+		ac, ok := actual.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(ex), len(ac)); i++ {
+			p := jsonPath(path, i)
+			switch {
+			case i >= len(ac):
+				diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", p, compactJSON(ex[i])))
+			case i >= len(ex):
+				diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", p, compactJSON(ac[i])))
+			default:
+				diffs = diffJSON(diffs, p, ex[i], ac[i])
+			}
+		}
+		return diffs
+
+	case json.Number:
+		if ac, ok := actual.(json.Number); ok && numbersEqual(ex, ac) {
+			return diffs
+		}
+
+	default:
+		if expected == actual {
+			return diffs
+		}
+	}
+
+	return append(diffs, fmt.Sprintf("%s: got %s, want %s", path, compactJSON(actual), compactJSON(expected)))
+}
+
+func numbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	x, okx := new(big.Rat).SetString(string(a))
+	y, oky := new(big.Rat).SetString(string(b))
+	return okx && oky && x.Cmp(y) == 0
+}
+
+func sortedKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, exists := a[k]; !exists {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Value passes the selected part of the document to a [Value] assertion. Objects become
+// map[string]any, arrays become []any and numbers become [json.Number].
+func (a JSONType) Value() AnyType[any] {
+	v := Value(a.actual, a.otherActual...).Info(a.pathInfo())
+	v.fault = a.fault
+	return v
+}
+
+// String passes the selected part of the document, which must be a JSON string,
+// to a [String] assertion.
+func (a JSONType) String() *StringType[string] {
+	s, isString := a.actual.(string)
+	v := String(s, a.otherActual...).Info(a.pathInfo())
+	v.fault = a.expectKind(isString, "a string")
+	return v
+}
+
+// Number passes the selected part of the document, which must be a JSON number,
+// to a [Number] assertion.
+func (a JSONType) Number() *OrderedType[float64] {
+	n, isNumber := a.actual.(json.Number)
+	f, _ := n.Float64()
+	v := Number(f, a.otherActual...).Info(a.pathInfo())
+	v.fault = a.expectKind(isNumber, "a number")
+	return v
+}
+
+// Bool passes the selected part of the document, which must be a JSON boolean,
+// to a [Bool] assertion.
+func (a JSONType) Bool() BoolType[bool] {
+	b, isBool := a.actual.(bool)
+	v := Bool(b, a.otherActual...).Info(a.pathInfo())
+	v.fault = a.expectKind(isBool, "a boolean")
+	return v
+}
+
+func (a JSONType) pathInfo() string {
+	if a.info == "" {
+		return "JSON " + a.path
+	}
+	return a.info + " JSON " + a.path
+}
+
+func (a JSONType) expectKind(ok bool, kind string) string {
+	if a.fault != "" || ok {
+		return a.fault
+	}
+	return fmt.Sprintf("it is %s, not %s", jsonKind(a.actual), kind)
+}
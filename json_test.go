@@ -0,0 +1,122 @@
+package expect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+const doc = `{
+	"name": "widget",
+	"price": 1.50,
+	"tags": ["a", "b"],
+	"stock": {"warehouse": 10, "shop": 2},
+	"active": true,
+	"a/b": null
+}`
+
+func TestJSONToBeEquivalentTo(t *testing.T) {
+	c := &capture{}
+
+	expect.JSON(doc).ToBeEquivalentTo(c, `{"active":true,"a/b":null,"stock":{"shop":2,"warehouse":1e1},"tags":["a","b"],"price":1.5,"name":"widget"}`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON([]byte(`[1, 2]`)).ToBeEquivalentTo(c, strings.NewReader(`[1.0,2]`))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(strings.NewReader(`[1, 2]`)).Not().ToBeEquivalentTo(c, `[2, 1]`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).I("response").ToBeEquivalentTo(c, `{"name":"gadget","price":1.5,"tags":["a"],"stock":{"warehouse":10,"shop":2},"active":true,"colour":"red"}`)
+	c.shouldHaveCalledErrorf(t, `Expected response JSON $ to be equivalent but there were 4 differences ―――
+$["a/b"]: unexpected null
+$.colour: missing, want "red"
+$.name: got "widget", want "gadget"
+$.tags[1]: unexpected "b"
+`)
+
+	expect.JSON(`{"a": [1, 2]}`).ToBeEquivalentTo(c, `{"a": {"b": 1}}`)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $ to be equivalent but there was one difference ―――\n"+
+		"$.a: got [1,2], want {\"b\":1}\n")
+
+	expect.JSON(`[1, 2]`).Not().ToBeEquivalentTo(c, `[1, 2.0]`)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $ not to be equivalent to ―――\n[1,2.0]\n")
+}
+
+func TestJSONInvalid(t *testing.T) {
+	c := &capture{}
+
+	expect.JSON(`{"a": `).ToBeEquivalentTo(c, `{"a": 1}`)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $ to be usable but the JSON could not be parsed: unexpected EOF.\n",
+		"Expected JSON $ to be equivalent but there was one difference ―――\n$: got null, want {\"a\":1}\n")
+
+	expect.JSON(`{}`).ToBeEquivalentTo(c, `{`)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $ to be compared with valid JSON but the JSON could not be parsed: unexpected EOF.\n")
+
+	expect.JSON(`{} []`).Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $ to be usable but the JSON has unexpected data after the top-level value.\n")
+
+	expect.JSON(123).Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $ to be usable but int is not []byte, string or io.Reader.\n")
+}
+
+func TestJSONAt(t *testing.T) {
+	c := &capture{}
+
+	expect.JSON(doc).At("/stock").ToBeEquivalentTo(c, `{"shop":2,"warehouse":10}`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("/tags/1").String().ToBe(c, "b")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("tags[0]").String().ToBe(c, "a")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("stock.shop").Number().ToBeLessThan(c, 3)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("/a~1b").Value().ToBeNil(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("active").Bool().ToBeTrue(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(doc).At("/stock").ToBeEquivalentTo(c, `{"shop":3,"warehouse":10}`)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $.stock to be equivalent but there was one difference ―――\n$.stock.shop: got 2, want 3\n")
+
+	expect.JSON(doc).At("price").Number().ToBe(c, 2)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $.price float64 ―――\n1.5\n――― to be ―――\n2\n")
+
+	expect.JSON(doc).At("name").String().ToBe(c, "gadget")
+	c.shouldHaveCalledErrorf(t, "Expected JSON $.name ―――\nwidget\n――― to be ―――\ngadget\n――― the first difference is at rune 0.\n")
+}
+
+func TestJSONAtInaccessible(t *testing.T) {
+	c := &capture{}
+
+	expect.JSON(doc).At("/stock/depot").Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $.stock.depot to be usable but $.stock has no member \"depot\".\n")
+
+	expect.JSON(doc).At("tags[2]").Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $.tags[2] to be usable but index 2 is out of range for $.tags with length 2.\n")
+
+	expect.JSON(doc).At("name.first").Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $.name.first to be usable but $.name is a string, which has no member \"first\".\n")
+
+	expect.JSON(doc).At("tags.x").Value().ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $.tags.x to be usable but $.tags is an array, which cannot be indexed by \"x\".\n")
+
+	expect.JSON(doc).I("doc").At("name").Number().ToBe(c, 0)
+	c.shouldHaveCalledFatalf(t, "Expected doc JSON $.name to be usable but it is a string, not a number.\n")
+}
+
+func ExampleJSON() {
+	var t *testing.T
+
+	response := `{"id": 7, "items": [{"name": "apple", "price": 0.5}]}`
+
+	expect.JSON(response).ToBeEquivalentTo(t, `{"items":[{"price":0.50,"name":"apple"}],"id":7}`)
+	expect.JSON(response).At("/items/0/name").String().ToBe(t, "apple")
+	expect.JSON(response).At("items[0].price").Number().ToBe(t, 0.5)
+}
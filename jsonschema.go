@@ -0,0 +1,255 @@
+package expect
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rickb777/plural"
+)
+
+// ToConformToSchema asserts that the JSON document conforms to a JSON Schema, which can be a []byte,
+// string or [io.Reader]. Every violation is listed with its path.
+//
+// A practical subset of JSON Schema is supported: "type", "enum", "const", "required", "properties",
+// "additionalProperties", "items", "pattern", "minLength", "maxLength", "minimum", "maximum",
+// "exclusiveMinimum", "exclusiveMaximum", "minItems" and "maxItems". Other keywords are ignored.
+// Patterns use Go's [regexp] syntax.
+// The tester is normally [*testing.T].
+func (a JSONType) ToConformToSchema(t Tester, schema any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.info = a.pathInfo()
+	a.allOtherArgumentsMustNotBeError(t)
+
+	s, err := parseJSON(schema)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Expected%s to be checked against a valid schema but %v.\n", preS(a.info), err))
+		return
+	}
+
+	violations := validateJSON(nil, a.path, a.actual, s)
+
+	if !a.not && len(violations) > 0 {
+		a.describeActualExpected1("to conform to the schema but %s ―――\n%s\n",
+			thereWereNViolations.FormatInt(len(violations)), strings.Join(violations, "\n"))
+	} else if a.not && len(violations) == 0 {
+		a.describeActualExpected1("not to conform to the schema.\n")
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+var thereWereNViolations = plural.FromOne("there was one violation", "there were %d violations")
+
+//-------------------------------------------------------------------------------------------------
+
+func validateJSON(violations []string, path string, v any, schema any) []string {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			violations = append(violations, fmt.Sprintf("%s: not allowed by the schema", path))
+		}
+		return violations
+	case map[string]any:
+		return validateJSONObject(violations, path, v, s)
+	}
+	return append(violations, fmt.Sprintf("%s: invalid schema %s", path, compactJSON(schema)))
+}
+
+func validateJSONObject(violations []string, path string, v any, schema map[string]any) []string {
+	if ty, exists := schema["type"]; exists {
+		types := schemaStrings(ty)
+		if !slices.ContainsFunc(types, func(name string) bool { return jsonHasType(v, name) }) {
+			// no further checks are useful if the type is wrong
+			return append(violations, fmt.Sprintf("%s: got %s, want type %s",
+				path, jsonKind(v), strings.Join(types, " or ")))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(e any) bool { return len(diffJSON(nil, "", e, v)) == 0 }) {
+			violations = append(violations, fmt.Sprintf("%s: %s is not one of %s", path, compactJSON(v), compactJSON(enum)))
+		}
+	}
+
+	if c, exists := schema["const"]; exists {
+		if len(diffJSON(nil, "", c, v)) > 0 {
+			violations = append(violations, fmt.Sprintf("%s: got %s, want %s", path, compactJSON(v), compactJSON(c)))
+		}
+	}
+
+	switch d := v.(type) {
+	case map[string]any:
+		violations = validateJSONProperties(violations, path, d, schema)
+
+	case []any:
+		violations = validateJSONItems(violations, path, d, schema)
+
+	case string:
+		n := utf8.RuneCountInString(d)
+		if minLength, ok := schemaNumber(schema, "minLength"); ok && big.NewRat(int64(n), 1).Cmp(minLength) < 0 {
+			violations = append(violations, fmt.Sprintf("%s: length %d is less than minLength %s", path, n, schema["minLength"]))
+		}
+		if maxLength, ok := schemaNumber(schema, "maxLength"); ok && big.NewRat(int64(n), 1).Cmp(maxLength) > 0 {
+			violations = append(violations, fmt.Sprintf("%s: length %d is more than maxLength %s", path, n, schema["maxLength"]))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s: invalid schema pattern %q: %v", path, pattern, err))
+			} else if !re.MatchString(d) {
+				violations = append(violations, fmt.Sprintf("%s: %q does not match pattern %q", path, d, pattern))
+			}
+		}
+
+	case json.Number:
+		violations = validateJSONNumber(violations, path, d, schema)
+	}
+
+	return violations
+}
+
+func validateJSONProperties(violations []string, path string, v map[string]any, schema map[string]any) []string {
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, exists := v[name]; !exists {
+			violations = append(violations, fmt.Sprintf("%s: required property %q is missing", path, name))
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional, hasAdditional := schema["additionalProperties"]
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := jsonPath(path, name)
+		if ps, exists := properties[name]; exists {
+			violations = validateJSON(violations, p, v[name], ps)
+		} else if hasAdditional {
+			if allowed, isBool := additional.(bool); isBool && !allowed {
+				violations = append(violations, fmt.Sprintf("%s: additional property is not allowed", p))
+			} else {
+				violations = validateJSON(violations, p, v[name], additional)
+			}
+		}
+	}
+
+	return violations
+}
+
+func validateJSONItems(violations []string, path string, v []any, schema map[string]any) []string {
+	if minItems, ok := schemaNumber(schema, "minItems"); ok && big.NewRat(int64(len(v)), 1).Cmp(minItems) < 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s fewer than minItems %s", path, nItems.FormatInt(len(v)), schema["minItems"]))
+	}
+	if maxItems, ok := schemaNumber(schema, "maxItems"); ok && big.NewRat(int64(len(v)), 1).Cmp(maxItems) > 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s more than maxItems %s", path, nItems.FormatInt(len(v)), schema["maxItems"]))
+	}
+
+	switch items := schema["items"].(type) {
+	case map[string]any, bool:
+		for i, e := range v {
+			violations = validateJSON(violations, jsonPath(path, i), e, items)
+		}
+	case []any:
+		// tuple validation, as in older drafts
+		for i, e := range v {
+			if i < len(items) {
+				violations = validateJSON(violations, jsonPath(path, i), e, items[i])
+			}
+		}
+	}
+
+	return violations
+}
+
+var nItems = plural.FromOne("1 item is", "%d items are")
+
+func validateJSONNumber(violations []string, path string, v json.Number, schema map[string]any) []string {
+	n, ok := new(big.Rat).SetString(string(v))
+	if !ok {
+		return violations
+	}
+
+	if minimum, ok := schemaNumber(schema, "minimum"); ok && n.Cmp(minimum) < 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s is less than the minimum %s", path, v, schema["minimum"]))
+	}
+	if maximum, ok := schemaNumber(schema, "maximum"); ok && n.Cmp(maximum) > 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s is more than the maximum %s", path, v, schema["maximum"]))
+	}
+	if minimum, ok := schemaNumber(schema, "exclusiveMinimum"); ok && n.Cmp(minimum) <= 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s is not more than the exclusive minimum %s", path, v, schema["exclusiveMinimum"]))
+	}
+	if maximum, ok := schemaNumber(schema, "exclusiveMaximum"); ok && n.Cmp(maximum) >= 0 {
+		violations = append(violations, fmt.Sprintf("%s: %s is not less than the exclusive maximum %s", path, v, schema["exclusiveMaximum"]))
+	}
+
+	return violations
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func jsonHasType(v any, name string) bool {
+	switch name {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		if n, ok := v.(json.Number); ok {
+			r, ok := new(big.Rat).SetString(string(n))
+			return ok && r.IsInt()
+		}
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	}
+	return false
+}
+
+func schemaStrings(v any) []string {
+	switch s := v.(type) {
+	case string:
+		return []string{s}
+	case []any:
+		list := make([]string, 0, len(s))
+		for _, e := range s {
+			if str, ok := e.(string); ok {
+				list = append(list, str)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func schemaNumber(schema map[string]any, keyword string) (*big.Rat, bool) {
+	n, ok := schema[keyword].(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(string(n))
+}
@@ -0,0 +1,77 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+const productSchema = `{
+	"type": "object",
+	"required": ["name", "price", "tags"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 3, "maxLength": 10, "pattern": "^[a-z]+$"},
+		"price": {"type": "number", "minimum": 0, "exclusiveMaximum": 100},
+		"count": {"type": "integer", "maximum": 1.5},
+		"tags": {"type": "array", "minItems": 1, "maxItems": 2, "items": {"enum": ["a", "b", "c"]}},
+		"stock": {"type": "object", "additionalProperties": {"type": "integer"}},
+		"active": {"type": ["boolean", "null"]}
+	}
+}`
+
+func TestJSONToConformToSchema(t *testing.T) {
+	c := &capture{}
+
+	expect.JSON(`{"name": "widget", "price": 1.5, "count": 1, "tags": ["a"], "stock": {"shop": 2}, "active": null}`).
+		ToConformToSchema(c, productSchema)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(`{"name": "widget", "price": 1.5, "tags": ["a"], "extra": 1}`).Not().ToConformToSchema(c, productSchema)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.JSON(`{"name": "Widgets galore", "price": 100, "count": 2.5, "tags": ["a", "x", "b"], "stock": {"shop": "two"}, "active": 1, "extra": 1}`).
+		I("product").ToConformToSchema(c, productSchema)
+	c.shouldHaveCalledErrorf(t, `Expected product JSON $ to conform to the schema but there were 9 violations ―――
+$.active: got a number, want type boolean or null
+$.count: got a number, want type integer
+$.extra: additional property is not allowed
+$.name: length 14 is more than maxLength 10
+$.name: "Widgets galore" does not match pattern "^[a-z]+$"
+$.price: 100 is not less than the exclusive maximum 100
+$.stock.shop: got a string, want type integer
+$.tags: 3 items are more than maxItems 2
+$.tags[1]: "x" is not one of ["a","b","c"]
+`)
+
+	expect.JSON(`{"price": -1, "tags": []}`).ToConformToSchema(c, productSchema)
+	c.shouldHaveCalledErrorf(t, `Expected JSON $ to conform to the schema but there were 3 violations ―――
+$: required property "name" is missing
+$.price: -1 is less than the minimum 0
+$.tags: 0 items are fewer than minItems 1
+`)
+
+	expect.JSON(`[1, 2]`).ToConformToSchema(c, productSchema)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $ to conform to the schema but there was one violation ―――\n$: got an array, want type object\n")
+
+	expect.JSON(`{"name": "ab", "price": 1, "tags": ["a"]}`).At("name").ToConformToSchema(c, `{"minLength": 3}`)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $.name to conform to the schema but there was one violation ―――\n$.name: length 2 is less than minLength 3\n")
+
+	expect.JSON(`{"name": "widget", "price": 1.5, "tags": ["a"]}`).Not().ToConformToSchema(c, productSchema)
+	c.shouldHaveCalledErrorf(t, "Expected JSON $ not to conform to the schema.\n")
+
+	expect.JSON(`{}`).ToConformToSchema(c, `{"type": `)
+	c.shouldHaveCalledFatalf(t, "Expected JSON $ to be checked against a valid schema but the JSON could not be parsed: unexpected EOF.\n")
+}
+
+func ExampleJSONType_ToConformToSchema() {
+	var t *testing.T
+
+	schema := `{
+		"type": "object",
+		"required": ["id"],
+		"properties": {"id": {"type": "integer", "minimum": 1}}
+	}`
+
+	expect.JSON(`{"id": 7}`).ToConformToSchema(t, schema)
+}
@@ -0,0 +1,388 @@
+package expect
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rickb777/plural"
+)
+
+// LogRecorder is an [slog.Handler] that records every log record, so that assertions can be made
+// about them using [Logs]. All levels are recorded. Groups are flattened into the attribute keys,
+// e.g. "request.id".
+type LogRecorder struct {
+	store  *logStore
+	attrs  []slog.Attr
+	groups []string
+}
+
+type logStore struct {
+	mu      sync.Mutex
+	records []logEntry
+}
+
+type logEntry struct {
+	level   slog.Level
+	message string
+	attrs   []slog.Attr
+}
+
+// NewLogRecorder creates an empty [LogRecorder]. Use it with [slog.New].
+func NewLogRecorder() *LogRecorder {
+	return &LogRecorder{store: &logStore{}}
+}
+
+// Enabled implements [slog.Handler]; it is always true.
+func (h *LogRecorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements [slog.Handler] by recording the record.
+func (h *LogRecorder) Handle(_ context.Context, r slog.Record) error {
+	e := logEntry{level: r.Level, message: r.Message, attrs: slices.Clone(h.attrs)}
+	prefix := groupPrefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		e.attrs = appendFlattened(e.attrs, prefix, a)
+		return true
+	})
+
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = append(h.store.records, e)
+	return nil
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *LogRecorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = slices.Clone(h.attrs)
+	prefix := groupPrefix(h.groups)
+	for _, a := range attrs {
+		h2.attrs = appendFlattened(h2.attrs, prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup implements [slog.Handler].
+func (h *LogRecorder) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// Reset discards the records recorded so far.
+func (h *LogRecorder) Reset() {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = nil
+}
+
+func (h *LogRecorder) snapshot() []logEntry {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	return slices.Clone(h.store.records)
+}
+
+func groupPrefix(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return strings.Join(groups, ".") + "."
+}
+
+func appendFlattened(attrs []slog.Attr, prefix string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix = prefix + a.Key + "."
+		}
+		for _, g := range a.Value.Group() {
+			attrs = appendFlattened(attrs, prefix, g)
+		}
+		return attrs
+	}
+
+	return append(attrs, slog.Attr{Key: prefix + a.Key, Value: a.Value})
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// LogsType is used for assertions about the records captured by a [LogRecorder].
+type LogsType struct {
+	actual []logEntry
+	assertion
+}
+
+// Logs creates an assertion about the records captured so far by a [LogRecorder].
+func Logs(recorder *LogRecorder) LogsType {
+	return LogsType{actual: recorder.snapshot()}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a LogsType) Info(info any, other ...any) LogsType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a LogsType) I(info any, other ...any) LogsType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a LogsType) Not() LogsType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToContainRecord asserts that there is a record at the given level whose message contains the
+// substring and which has all the attributes listed. The attributes are given in the same way as
+// for [slog.Logger.Info], i.e. as alternating keys and values, or as [slog.Attr]. Grouped
+// attributes are matched using keys such as "request.id".
+// The tester is normally [*testing.T].
+func (a LogsType) ToContainRecord(t Tester, level slog.Level, msgSubstring string, attrs ...any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	wanted := logEntry{level: level, message: msgSubstring}
+	for _, at := range argsToAttrs(attrs) {
+		wanted.attrs = appendFlattened(wanted.attrs, "", at)
+	}
+
+	_, found := a.find(wanted)
+
+	what := fmt.Sprintf("to contain a record ―――\n%s", formatLogEntry(wanted, true))
+
+	if !a.not && !found && len(a.actual) == 0 {
+		a.describeActualExpected1("logs %s――― but there were none.\n", what)
+	} else if (!a.not && !found) || (a.not && found) {
+		a.describeActualExpectedM("logs ―――\n%s", a.listing())
+		a.addExpectation("%s", what)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func (a LogsType) find(wanted logEntry) (logEntry, bool) {
+	for _, e := range a.actual {
+		if e.level == wanted.level && strings.Contains(e.message, wanted.message) && hasAllAttrs(e.attrs, wanted.attrs) {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+func hasAllAttrs(actual, wanted []slog.Attr) bool {
+	for _, w := range wanted {
+		if !slices.ContainsFunc(actual, func(a slog.Attr) bool { return attrEqual(a, w) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// attrEqual is like [slog.Attr.Equal] except that it compares slices, maps and other
+// non-comparable values deeply instead of panicking.
+func attrEqual(a, b slog.Attr) bool {
+	if a.Key != b.Key {
+		return false
+	}
+
+	av, bv := a.Value.Resolve(), b.Value.Resolve()
+	if av.Kind() != bv.Kind() {
+		return false
+	}
+
+	switch av.Kind() {
+	case slog.KindAny, slog.KindGroup:
+		return reflect.DeepEqual(av.Any(), bv.Any())
+	}
+	return av.Equal(bv)
+}
+
+// argsToAttrs converts alternating keys and values, or [slog.Attr] values, in the same way as slog.
+func argsToAttrs(args []any) []slog.Attr {
+	var attrs []slog.Attr
+	for len(args) > 0 {
+		switch x := args[0].(type) {
+		case slog.Attr:
+			attrs = append(attrs, x)
+			args = args[1:]
+		case string:
+			if len(args) == 1 {
+				attrs = append(attrs, slog.String("!BADKEY", x))
+				args = nil
+			} else {
+				attrs = append(attrs, slog.Any(x, args[1]))
+				args = args[2:]
+			}
+		default:
+			attrs = append(attrs, slog.Any("!BADKEY", x))
+			args = args[1:]
+		}
+	}
+	return attrs
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveCount asserts that there are the expected number of records.
+// The tester is normally [*testing.T].
+func (a LogsType) ToHaveCount(t Tester, expected int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && len(a.actual) != expected) || (a.not && len(a.actual) == expected) {
+		if len(a.actual) == 0 {
+			a.describeActualExpected1("logs %sto have %s but there were none.\n", notS(a.not), nRecords.FormatInt(expected))
+		} else {
+			a.describeActualExpectedM("logs ―――\n%s", a.listing())
+			a.addExpectation("to have %s.\n", nRecords.FormatInt(expected))
+		}
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToContainLevel asserts that there is at least one record at the given level or above.
+// It is often used as Not().ToContainLevel(t, slog.LevelError) to assert that no errors were logged.
+// The tester is normally [*testing.T].
+func (a LogsType) ToContainLevel(t Tester, level slog.Level) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	found := slices.ContainsFunc(a.actual, func(e logEntry) bool { return e.level >= level })
+
+	if !a.not && !found && len(a.actual) == 0 {
+		a.describeActualExpected1("logs to contain a record at level %s or above but there were none.\n", level)
+	} else if (!a.not && !found) || (a.not && found) {
+		a.describeActualExpectedM("logs ―――\n%s", a.listing())
+		a.addExpectation("to contain a record at level %s or above.\n", level)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Record selects the first record at the given level whose message contains the substring, so
+// that assertions can be made about its message and attributes.
+func (a LogsType) Record(level slog.Level, msgSubstring string) LogRecordType {
+	r := LogRecordType{info: strings.TrimSpace(fmt.Sprintf("%s %s record %q", a.info, level, msgSubstring))}
+	var found bool
+	r.actual, found = a.find(logEntry{level: level, message: msgSubstring})
+	if !found {
+		r.fault = "no such record was logged"
+	}
+	return r
+}
+
+var nRecords = plural.FromOne("one record", "%d records")
+
+func (a LogsType) listing() string {
+	var buf strings.Builder
+	for _, e := range a.actual {
+		buf.WriteString(formatLogEntry(e, false))
+	}
+	return buf.String()
+}
+
+// formatLogEntry writes the record in key=value form, similar to [slog.TextHandler].
+func formatLogEntry(e logEntry, substring bool) string {
+	var buf strings.Builder
+	buf.WriteString("level=")
+	buf.WriteString(e.level.String())
+	if substring {
+		buf.WriteString(" msg~")
+	} else {
+		buf.WriteString(" msg=")
+	}
+	buf.WriteString(quoteLogValue(e.message))
+	for _, at := range e.attrs {
+		buf.WriteByte(' ')
+		buf.WriteString(quoteLogValue(at.Key))
+		buf.WriteByte('=')
+		buf.WriteString(quoteLogValue(at.Value.String()))
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+func quoteLogValue(s string) string {
+	if s == "" || strings.ContainsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r)
+	}) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// LogRecordType holds one log record so that its message and attributes can be passed on to
+// other assertions.
+type LogRecordType struct {
+	actual logEntry
+	info   string
+	fault  string
+}
+
+// Message passes the record's message to a [String] assertion.
+func (r LogRecordType) Message() *StringType[string] {
+	s := String(r.actual.message).Info("message of %s", r.info)
+	s.fault = r.fault
+	return s
+}
+
+// Attr passes the value of an attribute to an [Any] assertion. Grouped attributes are selected
+// using keys such as "request.id". The value is as returned by [slog.Value.Any], so integers
+// are int64, for example.
+func (r LogRecordType) Attr(key string) AnyType[any] {
+	a := Any[any](nil).Info("attribute %s of %s", key, r.info)
+	a.fault = r.fault
+	if a.fault == "" {
+		i := slices.IndexFunc(r.actual.attrs, func(at slog.Attr) bool { return at.Key == key })
+		if i < 0 {
+			a.fault = "the record has no such attribute"
+		} else {
+			a.actual = r.actual.attrs[i].Value.Any()
+		}
+	}
+	return a
+}
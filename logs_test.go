@@ -0,0 +1,149 @@
+package expect_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func serviceLogs() *expect.LogRecorder {
+	rec := expect.NewLogRecorder()
+	log := slog.New(rec)
+	log.Info("started", "port", 8080)
+	log.With("component", "disk").Warn("disk space low", "free", 10, "path", "/var/lib")
+	log.WithGroup("request").Debug("handled", slog.Int("id", 42), slog.Group("user", "name", "ann"))
+	return rec
+}
+
+func TestLogsToContainRecord(t *testing.T) {
+	c := &capture{}
+	rec := serviceLogs()
+
+	expect.Logs(rec).ToContainRecord(c, slog.LevelWarn, "space", "free", 10)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).ToContainRecord(c, slog.LevelWarn, "disk", slog.String("component", "disk"), "path", "/var/lib")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).ToContainRecord(c, slog.LevelDebug, "handled", "request.id", 42, "request.user.name", "ann")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).ToContainRecord(c, slog.LevelDebug, "handled", slog.Group("request", "id", 42))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Not().ToContainRecord(c, slog.LevelError, "disk")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).I("service").ToContainRecord(c, slog.LevelWarn, "disk", "free", 5)
+	c.shouldHaveCalledErrorf(t, `Expected service logs ―――
+level=INFO msg=started port=8080
+level=WARN msg="disk space low" component=disk free=10 path=/var/lib
+level=DEBUG msg=handled request.id=42 request.user.name=ann
+――― to contain a record ―――
+level=WARN msg~disk free=5
+`)
+
+	expect.Logs(rec).Not().ToContainRecord(c, slog.LevelInfo, "start")
+	c.shouldHaveCalledErrorf(t, `Expected logs ―――
+level=INFO msg=started port=8080
+level=WARN msg="disk space low" component=disk free=10 path=/var/lib
+level=DEBUG msg=handled request.id=42 request.user.name=ann
+――― not to contain a record ―――
+level=INFO msg~start
+`)
+
+	sliced := expect.NewLogRecorder()
+	slog.New(sliced).Info("loaded", "ids", []int{1, 2}, "opts", map[string]bool{"x": true})
+
+	expect.Logs(sliced).ToContainRecord(c, slog.LevelInfo, "loaded", "ids", []int{1, 2}, "opts", map[string]bool{"x": true})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(sliced).Not().ToContainRecord(c, slog.LevelInfo, "loaded", "ids", []int{1, 3})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(expect.NewLogRecorder()).ToContainRecord(c, slog.LevelInfo, "started")
+	c.shouldHaveCalledErrorf(t, "Expected logs to contain a record ―――\nlevel=INFO msg~started\n――― but there were none.\n")
+}
+
+func TestLogsToHaveCount(t *testing.T) {
+	c := &capture{}
+	rec := serviceLogs()
+
+	expect.Logs(rec).ToHaveCount(c, 3)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).ToHaveCount(c, 1)
+	c.shouldHaveCalledErrorf(t, `Expected logs ―――
+level=INFO msg=started port=8080
+level=WARN msg="disk space low" component=disk free=10 path=/var/lib
+level=DEBUG msg=handled request.id=42 request.user.name=ann
+――― to have one record.
+`)
+
+	rec.Reset()
+	expect.Logs(rec).ToHaveCount(c, 0)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).ToHaveCount(c, 2)
+	c.shouldHaveCalledErrorf(t, "Expected logs to have 2 records but there were none.\n")
+}
+
+func TestLogsToContainLevel(t *testing.T) {
+	c := &capture{}
+	rec := serviceLogs()
+
+	expect.Logs(rec).ToContainLevel(c, slog.LevelWarn)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Not().ToContainLevel(c, slog.LevelError)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Not().ToContainLevel(c, slog.LevelInfo)
+	c.shouldHaveCalledErrorf(t, `Expected logs ―――
+level=INFO msg=started port=8080
+level=WARN msg="disk space low" component=disk free=10 path=/var/lib
+level=DEBUG msg=handled request.id=42 request.user.name=ann
+――― not to contain a record at level INFO or above.
+`)
+}
+
+func TestLogsRecord(t *testing.T) {
+	c := &capture{}
+	rec := serviceLogs()
+
+	expect.Logs(rec).Record(slog.LevelWarn, "disk").Attr("free").ToBe(c, int64(10))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Record(slog.LevelWarn, "disk").Message().ToContain(c, "low")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Record(slog.LevelDebug, "handled").Attr("request.user.name").ToBe(c, "ann")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Logs(rec).Record(slog.LevelWarn, "disk").Attr("path").ToBe(c, "/tmp")
+	c.shouldHaveCalledErrorf(t, `Expected attribute path of WARN record "disk" string ―――
+/var/lib
+――― to be ―――
+/tmp
+`)
+
+	expect.Logs(rec).Record(slog.LevelWarn, "disk").Attr("size").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, `Expected attribute size of WARN record "disk" to be usable but the record has no such attribute.`+"\n")
+
+	expect.Logs(rec).I("service").Record(slog.LevelError, "disk").Message().Not().ToBe(c, "x")
+	c.shouldHaveCalledFatalf(t, `Expected message of service ERROR record "disk" to be usable but no such record was logged.`+"\n")
+}
+
+func ExampleLogs() {
+	var t *testing.T
+
+	rec := expect.NewLogRecorder()
+	logger := slog.New(rec) // passed to the code under test
+
+	logger.Warn("disk space low", "free", 10)
+
+	expect.Logs(rec).ToContainRecord(t, slog.LevelWarn, "disk space", "free", 10)
+	expect.Logs(rec).Not().ToContainLevel(t, slog.LevelError)
+	expect.Logs(rec).Record(slog.LevelWarn, "disk space").Attr("free").ToBe(t, int64(10))
+}
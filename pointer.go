@@ -0,0 +1,145 @@
+package expect
+
+import (
+	"strings"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+// PointerType is used for assertions about pointers.
+type PointerType[T any] struct {
+	opts   gocmp.Options
+	actual *T
+	assertion
+}
+
+// Pointer creates an assertion about a pointer. Unlike [Value], this can distinguish between
+// pointers to the same variable and pointers to distinct but equal values.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+//
+// This uses [gocmp.Equal] so the manner of comparison can be tweaked using that API - see also [PointerType.Using]
+func Pointer[T any](value *T, other ...any) PointerType[T] {
+	return PointerType[T]{actual: value, opts: DefaultOptions(), assertion: assertion{otherActual: other}}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a PointerType[T]) Info(info any, other ...any) PointerType[T] {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a PointerType[T]) I(info any, other ...any) PointerType[T] {
+	return a.Info(info, other...)
+}
+
+// Using replaces the default comparison options with those specified here.
+// You can also set [DefaultOptions] instead.
+func (a PointerType[T]) Using(opt ...gocmp.Option) PointerType[T] {
+	a.opts = opt
+	return a
+}
+
+// Not inverts the assertion.
+func (a PointerType[T]) Not() PointerType[T] {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeNil asserts that the actual pointer is nil / is not nil.
+// The tester is normally [*testing.T].
+func (a PointerType[T]) ToBeNil(t Tester) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if !a.not && a.actual != nil {
+		a.describeActualExpected1("%T %p ―――\n%s――― to be nil.\n", a.actual, a.actual, verbatim2(*a.actual))
+	} else if a.not && a.actual == nil {
+		a.describeActualExpected1("%T not to be nil.\n", a.actual)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToPointTo asserts that the actual pointer is not nil and that the value it points to is
+// deeply equal to the expected value. With Not(), a nil pointer passes.
+// The tester is normally [*testing.T].
+func (a PointerType[T]) ToPointTo(t Tester, expected T) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if a.actual == nil {
+		if a.not {
+			a.passes++ // a nil pointer does not point to anything
+		} else {
+			a.describeActualExpected1("%T to point to ―――\n%s――― but it was nil.\n", a.actual, verbatim2(expected))
+		}
+		a.applyAll(t)
+		return
+	}
+
+	opts := append(a.opts, allowUnexported(gatherTypes(nil, *a.actual, expected)))
+
+	diffs := gocmp.Diff(expected, *a.actual, opts)
+
+	if !a.not && diffs != "" {
+		a.describeActualExpected1("%T %p to point to a value as shown (-want, +got) ―――\n", a.actual, a.actual)
+		a.addExpectation("%s", strings.ReplaceAll(diffs, " ", " "))
+	} else if a.not && diffs == "" {
+		a.describeActualExpected1("%T %p ", a.actual, a.actual)
+		a.addExpectation("to point to ―――\n%s", verbatim2(expected))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeSameAs asserts that the actual pointer holds the same address as the other pointer,
+// i.e. they point to the same variable, not merely to equal values.
+// The tester is normally [*testing.T].
+func (a PointerType[T]) ToBeSameAs(t Tester, other *T) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	same := a.actual == other
+
+	if (!a.not && !same) || (a.not && same) {
+		a.describeActualExpectedM("%T %p ―――\n%s", a.actual, a.actual, pointee(a.actual))
+		a.addExpectation("to be the same as %p ―――\n%s", other, pointee(other))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func pointee[T any](p *T) string {
+	if p == nil {
+		return "nil\n"
+	}
+	return verbatim2(*p)
+}
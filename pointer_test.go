@@ -0,0 +1,88 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestPointerToBeNil(t *testing.T) {
+	c := &capture{}
+
+	var p *point
+	expect.Pointer(p).ToBeNil(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(&point{1, 2}).Not().ToBeNil(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(&point{1, 2}).I("p").ToBeNil(c)
+	c.shouldHaveCalledErrorfRE(t, `^Expected p \*expect_test.point 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n――― to be nil.\n$`)
+
+	expect.Pointer(p).Not().ToBeNil(c)
+	c.shouldHaveCalledErrorf(t, "Expected *expect_test.point not to be nil.\n")
+}
+
+func TestPointerToPointTo(t *testing.T) {
+	c := &capture{}
+
+	expect.Pointer(&point{1, 2}).ToPointTo(c, point{1, 2})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(&point{1, 2}).Not().ToPointTo(c, point{1, 3})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(&point{1, 2}).ToPointTo(c, point{1, 3})
+	c.shouldHaveCalledErrorfRE(t, `^Expected \*expect_test.point 0x[0-9a-f]+ to point to a value as shown \(-want, \+got\) ―――\n`+
+		`(?s:.*)-\s+Y:\s+3,\n\+\s+Y:\s+2,\n(?s:.*)$`)
+
+	expect.Pointer(&point{1, 2}).Not().ToPointTo(c, point{1, 2})
+	c.shouldHaveCalledErrorfRE(t, `^Expected \*expect_test.point 0x[0-9a-f]+ not to point to ―――\n{X:1 Y:2}\n$`)
+
+	var p *point
+	expect.Pointer(p).ToPointTo(c, point{1, 2})
+	c.shouldHaveCalledErrorf(t, "Expected *expect_test.point to point to ―――\n{X:1 Y:2}\n――― but it was nil.\n")
+
+	expect.Pointer(p).Not().ToPointTo(c, point{1, 2})
+	c.shouldNotHaveHadAnError(t)
+}
+
+func TestPointerToBeSameAs(t *testing.T) {
+	c := &capture{}
+
+	p1 := &point{1, 2}
+	p2 := &point{1, 2}
+
+	expect.Pointer(p1).ToBeSameAs(c, p1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(p1).Not().ToBeSameAs(c, p2)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Pointer(p1).I("cached").ToBeSameAs(c, p2)
+	c.shouldHaveCalledErrorfRE(t, `^Expected cached \*expect_test.point 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n`+
+		`――― to be the same as 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n$`)
+
+	expect.Pointer(p1).ToBeSameAs(c, nil)
+	c.shouldHaveCalledErrorfRE(t, `^Expected \*expect_test.point 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n`+
+		`――― to be the same as 0x0 ―――\nnil\n$`)
+
+	expect.Pointer(p1).Not().ToBeSameAs(c, p1)
+	c.shouldHaveCalledErrorfRE(t, `^Expected \*expect_test.point 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n`+
+		`――― not to be the same as 0x[0-9a-f]+ ―――\n{X:1 Y:2}\n$`)
+}
+
+func ExamplePointerType_ToBeSameAs() {
+	var t *testing.T
+
+	cache := map[string]*point{"a": {1, 2}}
+	lookup := func(k string) *point { return cache[k] }
+
+	expect.Pointer(lookup("a")).ToBeSameAs(t, lookup("a"))
+	expect.Pointer(lookup("a")).ToPointTo(t, point{1, 2})
+	expect.Pointer(lookup("b")).ToBeNil(t)
+}
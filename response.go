@@ -0,0 +1,227 @@
+package expect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+)
+
+// ResponseType is used for assertions about HTTP responses.
+type ResponseType struct {
+	actual *http.Response
+	body   []byte
+	trim   int
+	assertion
+}
+
+// ResponseBodyTrim is the default length at which response bodies are trimmed in failure messages.
+// See [ResponseType.Trim].
+var ResponseBodyTrim = 500
+
+// Response creates an assertion about an HTTP response, either a [*http.Response] or the result
+// held by a [*httptest.ResponseRecorder]. The response body is read when the assertion is created
+// and is then restored, so it can still be read afterwards.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Response[R *http.Response | *httptest.ResponseRecorder](value R, other ...any) ResponseType {
+	a := ResponseType{trim: ResponseBodyTrim, assertion: assertion{otherActual: other}}
+
+	switch r := any(value).(type) {
+	case *http.Response:
+		a.actual = r
+	case *httptest.ResponseRecorder:
+		if r != nil {
+			a.actual = r.Result()
+		}
+	}
+
+	if a.actual == nil {
+		a.fault = "the response is nil"
+		a.actual = &http.Response{Header: http.Header{}}
+		return a
+	}
+
+	if a.actual.Body != nil {
+		body, err := io.ReadAll(a.actual.Body)
+		_ = a.actual.Body.Close()
+		if err != nil {
+			a.fault = fmt.Sprintf("the response body could not be read: %v", err)
+		}
+		a.body = body
+		a.actual.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a ResponseType) Info(info any, other ...any) ResponseType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a ResponseType) I(info any, other ...any) ResponseType {
+	return a.Info(info, other...)
+}
+
+// Trim shortens the body shown in error messages when it is very long. The default is
+// [ResponseBodyTrim]. See [StringType.Trim].
+func (a ResponseType) Trim(at int) ResponseType {
+	a.trim = at
+	return a
+}
+
+// Not inverts the assertion.
+func (a ResponseType) Not() ResponseType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveStatus asserts that the response has the expected status code.
+// The tester is normally [*testing.T].
+func (a ResponseType) ToHaveStatus(t Tester, code int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	match := a.actual.StatusCode == code
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("response ―――\n%s", a.dump())
+		a.addExpectation("to have status %d %s.\n", code, http.StatusText(code))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveHeader asserts that the response has a header with the given name. If a value
+// is also specified, at least one of the header's values must match it.
+// The tester is normally [*testing.T].
+func (a ResponseType) ToHaveHeader(t Tester, name string, value ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	values := a.actual.Header.Values(name)
+	match := len(values) > 0
+	what := fmt.Sprintf("to have header %s", http.CanonicalHeaderKey(name))
+	if len(value) > 0 {
+		match = slices.Contains(values, value[0])
+		what = fmt.Sprintf("%s: %s", what, value[0])
+	}
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("response ―――\n%s", a.dump())
+		a.addExpectation("%s\n", what)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveContentType asserts that the response has the expected content type. The media types
+// are compared case-insensitively. Parameters such as charset are only compared if they are
+// included in the expected content type.
+// The tester is normally [*testing.T].
+func (a ResponseType) ToHaveContentType(t Tester, contentType string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	match := contentTypeMatches(a.actual.Header.Get("Content-Type"), contentType)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("response ―――\n%s", a.dump())
+		a.addExpectation("to have content type %s\n", contentType)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func contentTypeMatches(actual, expected string) bool {
+	acType, acParams, err := mime.ParseMediaType(actual)
+	if err != nil {
+		return false
+	}
+
+	exType, exParams, err := mime.ParseMediaType(expected)
+	if err != nil || acType != exType {
+		return false
+	}
+
+	for k, v := range exParams {
+		if !strings.EqualFold(acParams[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Body passes the response body to a [String] assertion.
+func (a ResponseType) Body() *StringType[string] {
+	s := String(string(a.body), a.otherActual...).Info(prefix("body of ", a.info)).Trim(a.trim)
+	s.fault = a.fault
+	return s
+}
+
+// JSON passes the response body to a [JSON] assertion.
+func (a ResponseType) JSON() JSONType {
+	j := JSON(a.body, a.otherActual...).Info(prefix("body of ", a.info))
+	if a.fault != "" {
+		j.fault = a.fault
+	}
+	return j
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// dump renders the status line, headers and the trimmed body.
+func (a ResponseType) dump() string {
+	var buf strings.Builder
+	proto := a.actual.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	status := a.actual.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", a.actual.StatusCode, http.StatusText(a.actual.StatusCode))
+	}
+	fmt.Fprintf(&buf, "%s %s\n", proto, status)
+	_ = a.actual.Header.Write(&buf)
+	if len(a.body) > 0 {
+		buf.WriteString("\n")
+		buf.WriteString(trim(string(a.body), a.trim))
+		buf.WriteString("\n")
+	}
+	return strings.ReplaceAll(buf.String(), "\r\n", "\n")
+}
@@ -0,0 +1,174 @@
+package expect_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func okResponse() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Request-Id", "abc123")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.WriteString(`{"name":"apple","qty":2}`)
+	return w
+}
+
+func TestResponseToHaveStatus(t *testing.T) {
+	c := &capture{}
+
+	expect.Response(okResponse()).ToHaveStatus(c, http.StatusOK)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).Not().ToHaveStatus(c, http.StatusNotFound)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).I("GET /fruit").ToHaveStatus(c, http.StatusCreated)
+	c.shouldHaveCalledErrorf(t, `Expected GET /fruit response ―――
+HTTP/1.1 200 OK
+Content-Type: application/json; charset=utf-8
+X-Request-Id: abc123
+
+{"name":"apple","qty":2}
+――― to have status 201 Created.
+`)
+
+	expect.Response(okResponse()).Not().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledErrorf(t, `Expected response ―――
+HTTP/1.1 200 OK
+Content-Type: application/json; charset=utf-8
+X-Request-Id: abc123
+
+{"name":"apple","qty":2}
+――― not to have status 200 OK.
+`)
+
+	var nilResponse *http.Response
+	expect.Response(nilResponse).Not().ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the response is nil.\n")
+}
+
+func TestResponseToHaveStatus_trimmed(t *testing.T) {
+	c := &capture{}
+
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(strings.Repeat("x", 30))),
+	}
+	expect.Response(resp).Trim(10).ToHaveStatus(c, http.StatusOK)
+	c.shouldHaveCalledErrorf(t, `Expected response ―――
+HTTP/1.1 500 Internal Server Error
+
+xxxxxxxxxx…
+――― to have status 200 OK.
+`)
+}
+
+func TestResponseToHaveHeader(t *testing.T) {
+	c := &capture{}
+
+	expect.Response(okResponse()).ToHaveHeader(c, "x-request-id")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).ToHaveHeader(c, "X-Request-Id", "abc123")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).Not().ToHaveHeader(c, "Location")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).ToHaveHeader(c, "x-request-id", "xyz")
+	c.shouldHaveCalledErrorf(t, `Expected response ―――
+HTTP/1.1 200 OK
+Content-Type: application/json; charset=utf-8
+X-Request-Id: abc123
+
+{"name":"apple","qty":2}
+――― to have header X-Request-Id: xyz
+`)
+}
+
+func TestResponseToHaveContentType(t *testing.T) {
+	c := &capture{}
+
+	expect.Response(okResponse()).ToHaveContentType(c, "application/json")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).ToHaveContentType(c, "Application/JSON; charset=UTF-8")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).Not().ToHaveContentType(c, "text/html")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(okResponse()).ToHaveContentType(c, "application/json; charset=iso-8859-1")
+	c.shouldHaveCalledErrorf(t, `Expected response ―――
+HTTP/1.1 200 OK
+Content-Type: application/json; charset=utf-8
+X-Request-Id: abc123
+
+{"name":"apple","qty":2}
+――― to have content type application/json; charset=iso-8859-1
+`)
+}
+
+func TestResponseBody(t *testing.T) {
+	c := &capture{}
+
+	resp := okResponse().Result()
+	r := expect.Response(resp)
+
+	r.Body().ToContain(c, "apple")
+	c.shouldNotHaveHadAnError(t)
+
+	// the body can be inspected repeatedly and is still available afterwards
+	r.Body().ToBe(c, `{"name":"apple","qty":2}`)
+	c.shouldNotHaveHadAnError(t)
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil || string(b) != `{"name":"apple","qty":2}` {
+		t.Errorf("got %q, %v", b, err)
+	}
+
+	r.I("fruit").Body().ToContain(c, "pear")
+	c.shouldHaveCalledErrorf(t, `Expected body of fruit string len:24 ―――
+{"name":"apple","qty":2}
+――― to contain ―――
+pear
+`)
+
+	r.JSON().ToBeEquivalentTo(c, `{"qty": 2, "name": "apple"}`)
+	c.shouldNotHaveHadAnError(t)
+
+	r.JSON().At("qty").Number().ToBe(c, 2)
+	c.shouldNotHaveHadAnError(t)
+
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(failingReader{})}
+	expect.Response(resp).Body().ToBe(c, "")
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the response body could not be read: broken pipe.\n")
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func ExampleResponse() {
+	var t *testing.T
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+
+	expect.Response(w).ToHaveStatus(t, http.StatusOK)
+	expect.Response(w).ToHaveContentType(t, "application/json")
+	expect.Response(w).JSON().At("status").String().ToBe(t, "ok")
+}
@@ -0,0 +1,237 @@
+package expect
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rickb777/plural"
+)
+
+// RowsType is used for assertions about the results of database queries.
+type RowsType struct {
+	columns []string
+	actual  [][]string
+	assertion
+}
+
+// Rows creates an assertion about the results of a database query. All the rows are scanned
+// when the assertion is created and the rows are then closed. The values are converted to
+// strings: NULL is shown as "NULL", []byte as a string and [time.Time] in RFC3339 format.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Rows(rows *sql.Rows, other ...any) RowsType {
+	a := RowsType{assertion: assertion{otherActual: other}}
+
+	if rows == nil {
+		a.fault = "the rows are nil"
+		return a
+	}
+
+	var err error
+	a.columns, a.actual, err = scanAllRows(rows)
+	if err != nil {
+		a.fault = fmt.Sprintf("the rows could not be read: %v", err)
+	}
+	return a
+}
+
+func scanAllRows(rows *sql.Rows) (columns []string, values [][]string, err error) {
+	defer func() {
+		if e2 := rows.Close(); err == nil {
+			err = e2
+		}
+	}()
+
+	columns, err = rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cells := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range cells {
+		pointers[i] = &cells[i]
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return columns, values, err
+		}
+		row := make([]string, len(cells))
+		for i, c := range cells {
+			row[i] = formatSQLValue(c)
+		}
+		values = append(values, row)
+	}
+
+	return columns, values, rows.Err()
+}
+
+func formatSQLValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a RowsType) Info(info any, other ...any) RowsType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a RowsType) I(info any, other ...any) RowsType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a RowsType) Not() RowsType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveColumns asserts that the rows have the expected column names, in order.
+// The tester is normally [*testing.T].
+func (a RowsType) ToHaveColumns(t Tester, names ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	match := slices.Equal(a.columns, names)
+
+	if !a.not && !match {
+		a.describeActualExpected1("rows to have columns as shown (-want, +got; differing cells are *marked*) ―――\n%s",
+			renderTable(headerLines(diffTableRows([][]string{names}, [][]string{a.columns}))))
+	} else if a.not && match {
+		a.describeActualExpected1("rows not to have columns ―――\n%s", renderTable([]tableLine{{index: -1, cells: names}}))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveRowCount asserts that there are the expected number of rows.
+// The tester is normally [*testing.T].
+func (a RowsType) ToHaveRowCount(t Tester, expected int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual := len(a.actual)
+
+	if (!a.not && actual != expected) || (a.not && actual == expected) {
+		a.describeActualExpectedM("%s ―――\n%s", a.size(), a.render())
+		a.addExpectation("to have %s.\n", nRows.FormatInt(expected))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToContainRow asserts that there is a row with the expected values. The values are converted
+// to strings in the same way as the rows (see [Rows]) before being compared.
+// The tester is normally [*testing.T].
+func (a RowsType) ToContainRow(t Tester, values ...any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = formatSQLValue(v)
+	}
+
+	found := slices.ContainsFunc(a.actual, func(r []string) bool { return slices.Equal(r, row) })
+
+	if (!a.not && !found) || (a.not && found) {
+		a.describeActualExpectedM("%s ―――\n%s", a.size(), a.render())
+		a.addExpectation("to contain row ―――\n%s", renderTable([]tableLine{{index: -1, cells: row}}))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBe asserts that the rows have exactly the expected values, in order. The expected table
+// holds only the values, not the column names (see [RowsType.ToHaveColumns]).
+// The tester is normally [*testing.T].
+func (a RowsType) ToBe(t Tester, expected [][]string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	lines := diffTableRows(expected, a.actual)
+
+	if !a.not && lines != nil {
+		a.describeActualExpected1("%s to be as shown (-want, +got; differing cells are *marked*) ―――\n%s",
+			a.size(), renderTable(append([]tableLine{{index: -1, cells: a.columns}}, lines...)))
+	} else if a.not && lines == nil {
+		a.describeActualExpected1("%s not to be ―――\n%s", a.size(), a.render())
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Table passes the rows to a [Table] assertion, with the column names as the first row.
+func (a RowsType) Table() TableType {
+	tt := Table(append([][]string{a.columns}, a.actual...), a.otherActual...).Info(a.info)
+	tt.fault = a.fault
+	return tt
+}
+
+//-------------------------------------------------------------------------------------------------
+
+var nRows = plural.FromOne("one row", "%d rows")
+
+func (a RowsType) size() string {
+	return fmt.Sprintf("rows %d×%d", len(a.actual), len(a.columns))
+}
+
+// render shows the column names then the rows.
+func (a RowsType) render() string {
+	return renderTable(append([]tableLine{{index: -1, cells: a.columns}}, plainTableLines(a.actual)...))
+}
+
+// headerLines removes the row indexes, which are meaningless for column names.
+func headerLines(lines []tableLine) []tableLine {
+	for i := range lines {
+		lines[i].index = -1
+	}
+	return lines
+}
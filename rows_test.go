@@ -0,0 +1,133 @@
+package expect_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+var fruitDB = expect.OpenFakeDB(map[string]expect.FakeResult{
+	"SELECT name, price, picked FROM fruit": {
+		Columns: []string{"name", "price", "picked"},
+		Rows: [][]driver.Value{
+			{"apple", 0.5, time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)},
+			{[]byte("pear"), int64(75), nil},
+		},
+	},
+	"SELECT name FROM fruit WHERE 1=0": {
+		Columns: []string{"name"},
+	},
+	"SELECT broken": {
+		Columns: []string{"name"},
+		Rows:    [][]driver.Value{{"apple"}},
+		Err:     errors.New("connection lost"),
+	},
+})
+
+func queryFruit(t *testing.T, query string) *sql.Rows {
+	rows, err := fruitDB.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestRowsToHaveColumns(t *testing.T) {
+	c := &capture{}
+
+	expect.Rows(fruitDB.Query("SELECT name, price, picked FROM fruit")).ToHaveColumns(c, "name", "price", "picked")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).I("fruit").ToHaveColumns(c, "name", "cost", "picked")
+	c.shouldHaveCalledErrorf(t, `Expected fruit rows to have columns as shown (-want, +got; differing cells are *marked*) ―――
+-     | name | *cost*  | picked
++     | name | *price* | picked
+`)
+
+	expect.Rows(queryFruit(t, "SELECT name FROM fruit WHERE 1=0")).Not().ToHaveColumns(c, "name")
+	c.shouldHaveCalledErrorf(t, `Expected rows not to have columns ―――
+      | name
+`)
+}
+
+func TestRowsToHaveRowCount(t *testing.T) {
+	c := &capture{}
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToHaveRowCount(c, 2)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name FROM fruit WHERE 1=0")).ToHaveRowCount(c, 0)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToHaveRowCount(c, 1)
+	c.shouldHaveCalledErrorf(t, `Expected rows 2×3 ―――
+      | name  | price | picked
+    0 | apple | 0.5   | 2024-09-01T12:00:00Z
+    1 | pear  | 75    | NULL
+――― to have one row.
+`)
+
+	expect.Rows(queryFruit(t, "SELECT broken")).Not().ToHaveRowCount(c, 0)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the rows could not be read: connection lost.\n")
+}
+
+func TestRowsToContainRow(t *testing.T) {
+	c := &capture{}
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToContainRow(c, "pear", 75, nil)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToContainRow(c, "apple", 0.5, time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).Not().ToContainRow(c, "pear", 75, nil)
+	c.shouldHaveCalledErrorf(t, `Expected rows 2×3 ―――
+      | name  | price | picked
+    0 | apple | 0.5   | 2024-09-01T12:00:00Z
+    1 | pear  | 75    | NULL
+――― not to contain row ―――
+      | pear | 75 | NULL
+`)
+}
+
+func TestRowsToBe(t *testing.T) {
+	c := &capture{}
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToBe(c, [][]string{
+		{"apple", "0.5", "2024-09-01T12:00:00Z"},
+		{"pear", "75", "NULL"},
+	})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).ToBe(c, [][]string{
+		{"apple", "0.5", "2024-09-01T12:00:00Z"},
+		{"pear", "0.75", "NULL"},
+		{"plum", "1.25", "NULL"},
+	})
+	c.shouldHaveCalledErrorf(t, `Expected rows 2×3 to be as shown (-want, +got; differing cells are *marked*) ―――
+      | name  | price  | picked
+    0 | apple | 0.5    | 2024-09-01T12:00:00Z
+-   1 | pear  | *0.75* | NULL
++   1 | pear  | *75*   | NULL
+-   2 | plum  | 1.25   | NULL
+`)
+
+	expect.Rows(queryFruit(t, "SELECT name, price, picked FROM fruit")).Table().ToContainRow(c, "name", "price", "picked")
+	c.shouldNotHaveHadAnError(t)
+}
+
+func ExampleRows() {
+	var t *testing.T
+	var db *sql.DB
+
+	expect.Rows(db.Query("SELECT name, price FROM fruit ORDER BY name")).ToBe(t, [][]string{
+		{"apple", "0.5"},
+		{"pear", "0.75"},
+	})
+
+	expect.Rows(db.Query("SELECT name, price FROM fruit")).ToContainRow(t, "pear", 0.75)
+}
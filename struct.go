@@ -0,0 +1,251 @@
+package expect
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// StructType is used for assertions about fields within structs.
+type StructType[T any] struct {
+	actual T
+	assertion
+}
+
+// Struct creates an assertion about the fields of a struct (or a pointer to a struct).
+// Use [StructType.Field] or [StructType.FieldAt] to select a field, possibly deeply nested,
+// then make assertions about it. Unexported fields can be selected as well as exported ones.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Struct[T any](value T, other ...any) StructType[T] {
+	return StructType[T]{actual: value, assertion: assertion{otherActual: other}}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a StructType[T]) Info(info any, other ...any) StructType[T] {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a StructType[T]) I(info any, other ...any) StructType[T] {
+	return a.Info(info, other...)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Field selects a field using a path such as "Address.City" or "Items[2].Price". Field names
+// are separated by dots; slice and array elements are selected by index in square brackets,
+// as are map values by key (e.g. "Tags[colour]"). Pointers and interfaces are followed
+// automatically, as are promoted fields of embedded structs.
+//
+// The field is returned as an [AnyType] so that further assertions can be made about it; the
+// path is included in any failure message. If the path cannot be followed, e.g. because of a
+// nil pointer, the assertion fails.
+func (a StructType[T]) Field(path string) AnyType[any] {
+	steps, err := parseFieldPath(path)
+	if err != nil {
+		return a.selected(path, nil, err.Error())
+	}
+	return a.FieldAt(steps...).Info(a.fieldInfo(path))
+}
+
+// FieldAt selects a field using a sequence of steps. Each step is a string for a struct field
+// name or an int for a slice or array index; map keys are given as values of the map's key type.
+// For example, FieldAt("Items", 2, "Price") is equivalent to Field("Items[2].Price").
+func (a StructType[T]) FieldAt(steps ...any) AnyType[any] {
+	path := formatFieldPath(steps)
+
+	v := reflect.New(reflect.TypeFor[T]()).Elem() // addressable, so unexported fields are readable
+	v.Set(reflect.ValueOf(&a.actual).Elem())
+
+	for i, step := range steps {
+		var fault string
+		v, fault = selectStep(exported(v), step, formatFieldPath(steps[:i]))
+		if fault != "" {
+			return a.selected(path, nil, fault)
+		}
+	}
+
+	return a.selected(path, exported(v).Interface(), "")
+}
+
+func (a StructType[T]) selected(path string, value any, fault string) AnyType[any] {
+	s := Value[any](value, a.otherActual...).Info(a.fieldInfo(path))
+	s.fault = fault
+	return s
+}
+
+func (a StructType[T]) fieldInfo(path string) string {
+	if a.info == "" {
+		return "field " + path
+	}
+	return a.info + " field " + path
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// exported makes a value obtained via unexported fields readable, if it is addressable.
+// Values that are not addressable, such as map elements and interface contents, are copied
+// so that their own unexported fields can be read in turn.
+func exported(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	if !v.CanInterface() && v.CanAddr() {
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+
+	if !v.CanAddr() && v.CanInterface() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+
+	return v
+}
+
+func selectStep(v reflect.Value, step any, visited string) (reflect.Value, string) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, fmt.Sprintf("%s is nil", blankPath(visited))
+		}
+		v = exported(v.Elem())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		name, ok := step.(string)
+		if !ok {
+			return v, fmt.Sprintf("%s is a struct %s, which cannot be indexed by %v", blankPath(visited), v.Type(), step)
+		}
+		f := v.FieldByName(name)
+		if !f.IsValid() {
+			return v, fmt.Sprintf("%s has no field %s", v.Type(), name)
+		}
+		return f, ""
+
+	case reflect.Slice, reflect.Array, reflect.String:
+		i, ok := step.(int)
+		if !ok {
+			return v, fmt.Sprintf("%s is a %s, which has no field %v", blankPath(visited), v.Type(), step)
+		}
+		if i < 0 || i >= v.Len() {
+			return v, fmt.Sprintf("index %d is out of range for %s with len %d", i, blankPath(visited), v.Len())
+		}
+		return v.Index(i), ""
+
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), step)
+		if err != "" {
+			return v, fmt.Sprintf("%s is a %s; %s", blankPath(visited), v.Type(), err)
+		}
+		e := v.MapIndex(key)
+		if !e.IsValid() {
+			return v, fmt.Sprintf("%s has no key %v", blankPath(visited), step)
+		}
+		return e, ""
+	}
+
+	return v, fmt.Sprintf("%s is a %s, which has no field %v", blankPath(visited), v.Type(), step)
+}
+
+func mapKey(keyType reflect.Type, step any) (reflect.Value, string) {
+	k := reflect.ValueOf(step)
+	if k.Type().AssignableTo(keyType) {
+		return k, ""
+	}
+
+	switch keyType.Kind() {
+	case reflect.String:
+		switch k.Kind() {
+		case reflect.String:
+			return k.Convert(keyType), ""
+		case reflect.Int:
+			// numeric bracket keys are parsed as ints but can also be string keys
+			return reflect.ValueOf(strconv.FormatInt(k.Int(), 10)).Convert(keyType), ""
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if k.Kind() == reflect.Int {
+			return k.Convert(keyType), ""
+		}
+	}
+
+	return k, fmt.Sprintf("key %v is not a %s", step, keyType)
+}
+
+func blankPath(path string) string {
+	if path == "" {
+		return "the value"
+	}
+	return path
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func parseFieldPath(path string) ([]any, error) {
+	var steps []any
+	rest := path
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q is missing ']'", path)
+			}
+			index := rest[1:end]
+			if n, err := strconv.Atoi(index); err == nil {
+				// numeric indexes can also be used as integer map keys
+				steps = append(steps, n)
+			} else {
+				steps = append(steps, mapKeyString(index))
+			}
+			rest = rest[end+1:]
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			steps = append(steps, rest[:end])
+			rest = rest[end:]
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("path %q is empty", path)
+	}
+	return steps, nil
+}
+
+// mapKeyString is a string used as a map key, as distinct from a struct field name.
+type mapKeyString string
+
+func formatFieldPath(steps []any) string {
+	var buf strings.Builder
+	for _, step := range steps {
+		switch s := step.(type) {
+		case string:
+			if buf.Len() > 0 {
+				buf.WriteByte('.')
+			}
+			buf.WriteString(s)
+		default:
+			fmt.Fprintf(&buf, "[%v]", s)
+		}
+	}
+	return buf.String()
+}
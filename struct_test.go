@@ -0,0 +1,147 @@
+package expect_test
+
+import (
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+type address struct {
+	City     string
+	postcode string
+}
+
+type base struct {
+	ID int
+}
+
+type item struct {
+	Name  string
+	Price float64
+}
+
+type order struct {
+	base
+	Address *address
+	Items   []item
+	Tags    map[string]string
+	counts  map[int]int
+}
+
+var anOrder = order{
+	base:    base{ID: 7},
+	Address: &address{City: "Paris", postcode: "75001"},
+	Items:   []item{{"apple", 0.5}, {"pear", 0.75}, {"plum", 1.25}},
+	Tags:    map[string]string{"colour": "red"},
+	counts:  map[int]int{3: 9},
+}
+
+func TestStructField(t *testing.T) {
+	c := &capture{}
+
+	expect.Struct(anOrder).Field("Address.City").ToBe(c, "Paris")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(&anOrder).Field("Address.postcode").ToBe(c, "75001")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).Field("Items[2].Price").ToBe(c, 1.25)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).Field("ID").ToBe(c, 7)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).Field("base.ID").ToBe(c, 7)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).Field("Tags[colour]").ToBe(c, "red")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).Field("counts[3]").ToBe(c, 9)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).I("order").Field("Address.City").ToBe(c, "London")
+	c.shouldHaveCalledErrorf(t, "Expected order field Address.City string ―――\nParis\n――― to be ―――\nLondon\n")
+
+	expect.Struct(anOrder).Field("Items[1].Name").Not().ToBe(c, "pear")
+	c.shouldHaveCalledErrorf(t, "Expected field Items[1].Name string not to be ―――\npear\n")
+}
+
+func TestStructFieldInaccessible(t *testing.T) {
+	c := &capture{}
+
+	expect.Struct(order{}).Field("Address.City").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Address.City to be usable but Address is nil.\n")
+
+	expect.Struct(anOrder).Field("Items[5].Price").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Items[5].Price to be usable but index 5 is out of range for Items with len 3.\n")
+
+	expect.Struct(anOrder).Field("Address.Town").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Address.Town to be usable but expect_test.address has no field Town.\n")
+
+	expect.Struct(anOrder).Field("Tags[size]").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Tags[size] to be usable but Tags has no key size.\n")
+
+	expect.Struct(anOrder).Field("Items.Price").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Items.Price to be usable but Items is a []expect_test.item, which has no field Price.\n")
+
+	expect.Struct(anOrder).Field("Items[1").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Items[1 to be usable but path \"Items[1\" is missing ']'.\n")
+}
+
+func TestStructFieldAt(t *testing.T) {
+	c := &capture{}
+
+	expect.Struct(anOrder).FieldAt("Items", 0, "Name").ToBe(c, "apple")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).FieldAt("counts", 3).ToBe(c, 9)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(anOrder).FieldAt("Items", 0, "Name").ToBe(c, "pear")
+	c.shouldHaveCalledErrorf(t, "Expected field Items[0].Name string ―――\napple\n――― to be ―――\npear\n")
+
+	expect.Struct(anOrder).FieldAt("Tags", 1).ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field Tags[1] to be usable but Tags has no key 1.\n")
+
+	expect.Struct(anOrder).FieldAt("counts", "x").ToBeNil(c)
+	c.shouldHaveCalledFatalf(t, "Expected field counts.x to be usable but counts is a map[int]int; key x is not a int.\n")
+}
+
+type catalogue struct {
+	Addresses map[string]address
+	Codes     map[string]string
+	Default   any
+	extra     any
+}
+
+func TestStructFieldNotAddressable(t *testing.T) {
+	c := &capture{}
+
+	cat := catalogue{
+		Addresses: map[string]address{"home": {City: "Paris", postcode: "75001"}},
+		Codes:     map[string]string{"404": "not found"},
+		Default:   address{City: "Lyon", postcode: "69001"},
+		extra:     item{Name: "fig", Price: 2},
+	}
+
+	expect.Struct(cat).Field("Addresses[home].postcode").ToBe(c, "75001")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(cat).Field("Default.postcode").ToBe(c, "69001")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(&cat).Field("extra.Name").ToBe(c, "fig")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Struct(cat).Field("Codes[404]").ToBe(c, "not found")
+	c.shouldNotHaveHadAnError(t)
+}
+
+func ExampleStructType_Field() {
+	var t *testing.T
+
+	expect.Struct(anOrder).Field("Address.City").ToBe(t, "Paris")
+	expect.Struct(anOrder).Field("Items[2].Price").ToBe(t, 1.25)
+	expect.Struct(anOrder).FieldAt("Items", 2, "Price").ToBe(t, 1.25)
+}
@@ -0,0 +1,296 @@
+package expect
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableType is used for assertions about tabular data, such as CSV.
+type TableType struct {
+	actual      [][]string
+	ignoreOrder bool
+	assertion
+}
+
+// Table creates an assertion about tabular data. When there is a header row, it should be the
+// first row. Failures are shown as an aligned text table in which differing cells are marked.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Table(rows [][]string, other ...any) TableType {
+	return TableType{actual: rows, assertion: assertion{otherActual: other}}
+}
+
+// TableFromCSV creates an assertion about tabular data read from a CSV reader (see [Table]).
+// If the data cannot be read, assertions will fail.
+func TableFromCSV(r *csv.Reader) TableType {
+	rows, err := r.ReadAll()
+	a := Table(rows)
+	if err != nil {
+		a.fault = fmt.Sprintf("the CSV could not be read: %v", err)
+	}
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a TableType) Info(info any, other ...any) TableType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a TableType) I(info any, other ...any) TableType {
+	return a.Info(info, other...)
+}
+
+// IgnoreRowOrder makes [TableType.ToBe] compare the rows in any order.
+func (a TableType) IgnoreRowOrder() TableType {
+	a.ignoreOrder = true
+	return a
+}
+
+// Not inverts the assertion.
+func (a TableType) Not() TableType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBe asserts that the actual and expected tables have the same rows and cells. Unless
+// [TableType.IgnoreRowOrder] is used, the rows must be in the same order.
+// The tester is normally [*testing.T].
+func (a TableType) ToBe(t Tester, expected [][]string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	var lines []tableLine
+	if a.ignoreOrder {
+		lines = diffTableRowsInAnyOrder(expected, a.actual)
+	} else {
+		lines = diffTableRows(expected, a.actual)
+	}
+
+	if !a.not && lines != nil {
+		a.describeActualExpected1("%s to be as shown (-want, +got; differing cells are *marked*) ―――\n%s",
+			tableSize(a.actual), renderTable(lines))
+	} else if a.not && lines == nil {
+		a.describeActualExpected1("%s not to be ―――\n%s", tableSize(a.actual), renderTable(plainTableLines(a.actual)))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveColumns asserts that the first row of the table holds the expected column names, in order.
+// The tester is normally [*testing.T].
+func (a TableType) ToHaveColumns(t Tester, names ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	var header []string
+	if len(a.actual) > 0 {
+		header = a.actual[0]
+	}
+
+	match := slices.Equal(header, names)
+
+	if !a.not && !match {
+		a.describeActualExpected1("%s to have columns as shown (-want, +got; differing cells are *marked*) ―――\n%s",
+			tableSize(a.actual), renderTable(diffTableRows([][]string{names}, [][]string{header})))
+	} else if a.not && match {
+		a.describeActualExpected1("%s not to have columns ―――\n%s", tableSize(a.actual), renderTable(plainTableLines([][]string{names})))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToContainRow asserts that the table contains a row with exactly the expected cells.
+// The tester is normally [*testing.T].
+func (a TableType) ToContainRow(t Tester, row ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	found := slices.ContainsFunc(a.actual, func(r []string) bool { return slices.Equal(r, row) })
+
+	if (!a.not && !found) || (a.not && found) {
+		a.describeActualExpectedM("%s ―――\n%s", tableSize(a.actual), renderTable(plainTableLines(a.actual)))
+		a.addExpectation("to contain row ―――\n%s", renderTable([]tableLine{{index: -1, cells: row}}))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//=================================================================================================
+
+type tableLine struct {
+	marker string
+	index  int
+	cells  []string
+	marked []bool
+}
+
+func tableSize(rows [][]string) string {
+	columns := 0
+	for _, r := range rows {
+		columns = max(columns, len(r))
+	}
+	return fmt.Sprintf("table %d×%d", len(rows), columns)
+}
+
+func plainTableLines(rows [][]string) []tableLine {
+	lines := make([]tableLine, len(rows))
+	for i, r := range rows {
+		lines[i] = tableLine{index: i, cells: r}
+	}
+	return lines
+}
+
+// diffTableRows aligns the rows using their longest common subsequence, so that inserted or
+// removed rows don't affect the rows that follow. Within each changed hunk, removed and added
+// rows are paired in order and their differing cells are marked. It returns nil if there are
+// no differences.
+func diffTableRows(expected, actual [][]string) []tableLine {
+	script := editScript(expected, actual, slices.Equal[[]string])
+	if !slices.ContainsFunc(script, func(e edit) bool { return e.op != ' ' }) {
+		return nil
+	}
+
+	var lines []tableLine
+	for k := 0; k < len(script); {
+		if script[k].op == ' ' {
+			lines = append(lines, tableLine{index: script[k].ac, cells: actual[script[k].ac]})
+			k++
+			continue
+		}
+
+		// gather the changed hunk
+		var removed, added []int
+		for ; k < len(script) && script[k].op != ' '; k++ {
+			if script[k].op == '-' {
+				removed = append(removed, script[k].ex)
+			} else {
+				added = append(added, script[k].ac)
+			}
+		}
+
+		for n := 0; n < max(len(removed), len(added)); n++ {
+			var marked []bool
+			if n < len(removed) && n < len(added) {
+				marked = markCells(expected[removed[n]], actual[added[n]])
+			}
+			if n < len(removed) {
+				lines = append(lines, tableLine{marker: "-", index: removed[n], cells: expected[removed[n]], marked: marked})
+			}
+			if n < len(added) {
+				lines = append(lines, tableLine{marker: "+", index: added[n], cells: actual[added[n]], marked: marked})
+			}
+		}
+	}
+
+	return lines
+}
+
+func markCells(expected, actual []string) []bool {
+	marked := make([]bool, max(len(expected), len(actual)))
+	for j := range marked {
+		marked[j] = j >= len(expected) || j >= len(actual) || expected[j] != actual[j]
+	}
+	return marked
+}
+
+// diffTableRowsInAnyOrder lists the missing and unexpected rows. It returns nil if there are no differences.
+func diffTableRowsInAnyOrder(expected, actual [][]string) []tableLine {
+	var lines []tableLine
+	used := make([]bool, len(actual))
+
+	for i, ex := range expected {
+		j := -1
+		for k, r := range actual {
+			if !used[k] && slices.Equal(r, ex) {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			lines = append(lines, tableLine{marker: "-", index: i, cells: ex})
+		} else {
+			used[j] = true
+		}
+	}
+
+	for j, r := range actual {
+		if !used[j] {
+			lines = append(lines, tableLine{marker: "+", index: j, cells: r})
+		}
+	}
+
+	return lines
+}
+
+func renderTable(lines []tableLine) string {
+	var widths []int
+	for _, line := range lines {
+		for j, c := range line.cells {
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], utf8.RuneCountInString(markCell(c, line.isMarked(j))))
+		}
+	}
+
+	var buf strings.Builder
+	for _, line := range lines {
+		if line.index < 0 {
+			fmt.Fprintf(&buf, "%1s    ", line.marker)
+		} else {
+			fmt.Fprintf(&buf, "%1s %3d", line.marker, line.index)
+		}
+		for j, c := range line.cells {
+			cell := markCell(c, line.isMarked(j))
+			buf.WriteString(" | ")
+			buf.WriteString(cell)
+			if j < len(line.cells)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func (l tableLine) isMarked(j int) bool {
+	return j < len(l.marked) && l.marked[j]
+}
+
+func markCell(c string, marked bool) string {
+	if marked {
+		return "*" + c + "*"
+	}
+	return c
+}
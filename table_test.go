@@ -0,0 +1,116 @@
+package expect_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+var fruit = [][]string{
+	{"name", "price", "qty"},
+	{"apple", "0.5", "2"},
+	{"pear", "0.75", "1"},
+}
+
+func TestTableToBe(t *testing.T) {
+	c := &capture{}
+
+	expect.Table(fruit).ToBe(c, [][]string{{"name", "price", "qty"}, {"apple", "0.5", "2"}, {"pear", "0.75", "1"}})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.TableFromCSV(csv.NewReader(strings.NewReader("name,price,qty\napple,0.5,2\npear,0.75,1\n"))).ToBe(c, fruit)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Table(fruit).I("report").ToBe(c, [][]string{{"name", "price", "qty"}, {"apple", "0.5", "2"}, {"pear", "0.7", "1"}, {"plum", "1.25", "1"}})
+	c.shouldHaveCalledErrorf(t, `Expected report table 3×3 to be as shown (-want, +got; differing cells are *marked*) ―――
+    0 | name  | price  | qty
+    1 | apple | 0.5    | 2
+-   2 | pear  | *0.7*  | 1
++   2 | pear  | *0.75* | 1
+-   3 | plum  | 1.25   | 1
+`)
+
+	expect.Table([][]string{{"a"}, {"x"}, {"b"}, {"c"}}).ToBe(c, [][]string{{"a"}, {"b"}, {"c"}})
+	c.shouldHaveCalledErrorf(t, `Expected table 4×1 to be as shown (-want, +got; differing cells are *marked*) ―――
+    0 | a
++   1 | x
+    2 | b
+    3 | c
+`)
+
+	expect.Table(fruit).Not().ToBe(c, fruit)
+	c.shouldHaveCalledErrorf(t, `Expected table 3×3 not to be ―――
+    0 | name  | price | qty
+    1 | apple | 0.5   | 2
+    2 | pear  | 0.75  | 1
+`)
+
+	expect.TableFromCSV(csv.NewReader(strings.NewReader("a,b\nc\n"))).ToBe(c, nil)
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the CSV could not be read: record on line 2: wrong number of fields.\n")
+}
+
+func TestTableToBeIgnoringRowOrder(t *testing.T) {
+	c := &capture{}
+
+	expect.Table(fruit).IgnoreRowOrder().ToBe(c, [][]string{{"pear", "0.75", "1"}, {"name", "price", "qty"}, {"apple", "0.5", "2"}})
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Table(fruit).IgnoreRowOrder().ToBe(c, [][]string{{"pear", "0.75", "1"}, {"name", "price", "qty"}, {"plum", "1.25", "1"}})
+	c.shouldHaveCalledErrorf(t, `Expected table 3×3 to be as shown (-want, +got; differing cells are *marked*) ―――
+-   2 | plum  | 1.25 | 1
++   1 | apple | 0.5  | 2
+`)
+}
+
+func TestTableToHaveColumns(t *testing.T) {
+	c := &capture{}
+
+	expect.Table(fruit).ToHaveColumns(c, "name", "price", "qty")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Table(fruit).ToHaveColumns(c, "name", "cost", "qty")
+	c.shouldHaveCalledErrorf(t, `Expected table 3×3 to have columns as shown (-want, +got; differing cells are *marked*) ―――
+-   0 | name | *cost*  | qty
++   0 | name | *price* | qty
+`)
+
+	expect.Table(fruit).Not().ToHaveColumns(c, "name", "price", "qty")
+	c.shouldHaveCalledErrorf(t, "Expected table 3×3 not to have columns ―――\n    0 | name | price | qty\n")
+}
+
+func TestTableToContainRow(t *testing.T) {
+	c := &capture{}
+
+	expect.Table(fruit).ToContainRow(c, "pear", "0.75", "1")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Table(fruit).ToContainRow(c, "plum", "1.25", "1")
+	c.shouldHaveCalledErrorf(t, `Expected table 3×3 ―――
+    0 | name  | price | qty
+    1 | apple | 0.5   | 2
+    2 | pear  | 0.75  | 1
+――― to contain row ―――
+      | plum | 1.25 | 1
+`)
+
+	expect.Table(fruit).Not().ToContainRow(c, "pear", "0.75", "1")
+	c.shouldHaveCalledErrorf(t, `Expected table 3×3 ―――
+    0 | name  | price | qty
+    1 | apple | 0.5   | 2
+    2 | pear  | 0.75  | 1
+――― not to contain row ―――
+      | pear | 0.75 | 1
+`)
+}
+
+func ExampleTable() {
+	var t *testing.T
+
+	report := csv.NewReader(strings.NewReader("name,qty\napple,2\npear,1\n"))
+
+	tbl := expect.TableFromCSV(report)
+	tbl.ToHaveColumns(t, "name", "qty")
+	tbl.ToContainRow(t, "pear", "1")
+}
@@ -0,0 +1,178 @@
+package expect
+
+import (
+	"time"
+)
+
+// TimeType is used for assertions about times.
+type TimeType struct {
+	actual time.Time
+	assertion
+}
+
+// Time creates an assertion about a [time.Time]. Failure messages render times using
+// [time.RFC3339Nano].
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func Time(value time.Time, other ...any) TimeType {
+	return TimeType{actual: value, assertion: assertion{otherActual: other}}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a TimeType) Info(info any, other ...any) TimeType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a TimeType) I(info any, other ...any) TimeType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a TimeType) Not() TimeType {
+	a.not = !a.not
+	return a
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBe asserts that the actual and expected times represent the same instant (see [time.Time.Equal]).
+// The tester is normally [*testing.T].
+func (a TimeType) ToBe(t Tester, expected time.Time) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual.Equal(expected), "to be", expected)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeBefore asserts that the actual time is before the threshold.
+// The tester is normally [*testing.T].
+func (a TimeType) ToBeBefore(t Tester, threshold time.Time) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual.Before(threshold), "to be before", threshold)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeAfter asserts that the actual time is after the threshold.
+// The tester is normally [*testing.T].
+func (a TimeType) ToBeAfter(t Tester, threshold time.Time) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toCompare(t, a.actual.After(threshold), "to be after", threshold)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func (a TimeType) toCompare(t Tester, match bool, what string, expected time.Time) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("time ―――\n%s\n", formatTime(a.actual))
+		a.addExpectation("%s ―――\n%s\n", what, formatTime(expected))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeWithin asserts that the actual time is no further than the tolerance from
+// the expected time, either before or after it.
+// The tester is normally [*testing.T].
+func (a TimeType) ToBeWithin(t Tester, tolerance time.Duration, expected time.Time) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	difference := a.actual.Sub(expected)
+	match := difference.Abs() <= tolerance
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpectedM("time ―――\n%s\n", formatTime(a.actual))
+		a.addExpectation("to be within %s of ―――\n%s\n――― the difference is %s.\n",
+			tolerance, formatTime(expected), difference)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeInLocation asserts that the actual time has the expected location (time zone).
+// Locations are compared by name.
+// The tester is normally [*testing.T].
+func (a TimeType) ToBeInLocation(t Tester, expected *time.Location) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual := a.actual.Location()
+	match := actual.String() == expected.String()
+
+	if (!a.not && !match) || (a.not && match) {
+		a.describeActualExpected1("time %s in location %q %sto be in location %q.\n",
+			formatTime(a.actual), actual, notS(a.not), expected)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeTruncatedTo asserts that the actual time is a whole multiple of the duration,
+// i.e. it is unchanged by [time.Time.Truncate].
+// The tester is normally [*testing.T].
+func (a TimeType) ToBeTruncatedTo(t Tester, d time.Duration) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	truncated := a.actual.Truncate(d)
+	match := truncated.Equal(a.actual)
+
+	if !a.not && !match {
+		a.describeActualExpected1("time %s to be truncated to %s; the remainder is %s.\n",
+			formatTime(a.actual), d, a.actual.Sub(truncated))
+	} else if a.not && match {
+		a.describeActualExpected1("time %s not to be truncated to %s.\n", formatTime(a.actual), d)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
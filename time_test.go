@@ -0,0 +1,100 @@
+package expect_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rickb777/expect"
+)
+
+var (
+	t0 = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 = t0.Add(1500 * time.Millisecond)
+)
+
+func TestTimeToBe(t *testing.T) {
+	c := &capture{}
+
+	expect.Time(t0).ToBe(c, t0.In(time.FixedZone("X", 3600)))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t0, nil).Not().ToBe(c, t1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t0).I("start").ToBe(c, t1)
+	c.shouldHaveCalledErrorf(t, "Expected start time ―――\n2025-06-01T12:00:00Z\n――― to be ―――\n2025-06-01T12:00:01.5Z\n")
+
+	expect.Time(t0).Not().ToBe(c, t0)
+	c.shouldHaveCalledErrorf(t, "Expected time ―――\n2025-06-01T12:00:00Z\n――― not to be ―――\n2025-06-01T12:00:00Z\n")
+
+	expect.Time(t0, e1).ToBe(c, t0)
+	c.shouldHaveCalledFatalf(t, "Expected not to pass a non-nil error but got error parameter 2 ―――\nsomething bad happened\n")
+}
+
+func TestTimeToBeBeforeAfter(t *testing.T) {
+	c := &capture{}
+
+	expect.Time(t0).ToBeBefore(c, t1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t1).ToBeAfter(c, t0)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t1).ToBeBefore(c, t0)
+	c.shouldHaveCalledErrorf(t, "Expected time ―――\n2025-06-01T12:00:01.5Z\n――― to be before ―――\n2025-06-01T12:00:00Z\n")
+
+	expect.Time(t1).Not().ToBeAfter(c, t0)
+	c.shouldHaveCalledErrorf(t, "Expected time ―――\n2025-06-01T12:00:01.5Z\n――― not to be after ―――\n2025-06-01T12:00:00Z\n")
+}
+
+func TestTimeToBeWithin(t *testing.T) {
+	c := &capture{}
+
+	expect.Time(t1).ToBeWithin(c, 2*time.Second, t0)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t0).ToBeWithin(c, 2*time.Second, t1)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t1).ToBeWithin(c, time.Second, t0)
+	c.shouldHaveCalledErrorf(t, "Expected time ―――\n2025-06-01T12:00:01.5Z\n"+
+		"――― to be within 1s of ―――\n2025-06-01T12:00:00Z\n――― the difference is 1.5s.\n")
+}
+
+func TestTimeToBeInLocation(t *testing.T) {
+	c := &capture{}
+
+	expect.Time(t0).ToBeInLocation(c, time.UTC)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t0).ToBeInLocation(c, time.Local)
+	c.shouldHaveCalledErrorf(t, "Expected time 2025-06-01T12:00:00Z in location \"UTC\" to be in location \"Local\".\n")
+
+	expect.Time(t0).Not().ToBeInLocation(c, time.UTC)
+	c.shouldHaveCalledErrorf(t, "Expected time 2025-06-01T12:00:00Z in location \"UTC\" not to be in location \"UTC\".\n")
+}
+
+func TestTimeToBeTruncatedTo(t *testing.T) {
+	c := &capture{}
+
+	expect.Time(t0).ToBeTruncatedTo(c, time.Second)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t1).Not().ToBeTruncatedTo(c, time.Second)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Time(t1).ToBeTruncatedTo(c, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected time 2025-06-01T12:00:01.5Z to be truncated to 1s; the remainder is 500ms.\n")
+
+	expect.Time(t0).Not().ToBeTruncatedTo(c, time.Second)
+	c.shouldHaveCalledErrorf(t, "Expected time 2025-06-01T12:00:00Z not to be truncated to 1s.\n")
+}
+
+func ExampleTimeType_ToBeWithin() {
+	var t *testing.T
+
+	start := time.Now()
+	// ... something under test goes here
+	expect.Time(time.Now()).ToBeAfter(t, start)
+	expect.Time(time.Now()).ToBeWithin(t, time.Second, start)
+}
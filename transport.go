@@ -0,0 +1,340 @@
+package expect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rickb777/plural"
+)
+
+// RecordingTransport is an [http.RoundTripper] test double. It records every outgoing request
+// and serves canned responses, so that HTTP clients can be tested without a live service.
+// Use [Transport] to make assertions about the requests it received.
+type RecordingTransport struct {
+	mu       sync.Mutex
+	canned   []cannedResponse
+	received []RecordedRequest
+}
+
+type cannedResponse struct {
+	method, path string
+	status       int
+	header       http.Header
+	body         []byte
+}
+
+// RecordedRequest holds a request received by a [RecordingTransport].
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// String returns the method and URL of the request.
+func (r RecordedRequest) String() string {
+	return r.Method + " " + r.URL.String()
+}
+
+// NewRecordingTransport creates a [RecordingTransport] with no canned responses.
+func NewRecordingTransport() *RecordingTransport {
+	return &RecordingTransport{}
+}
+
+// Respond adds a canned response for requests with the given method and path. An empty method
+// or path matches any request. If the path contains a query, the query must also match.
+// The body can be a []byte, a string or an [io.Reader], or nil for an empty body. Headers are given as name/value pairs.
+//
+// The first matching canned response is used; requests that match none of them get a
+// 404 Not Found response.
+func (rt *RecordingTransport) Respond(method, path string, status int, body any, header ...string) *RecordingTransport {
+	if len(header)%2 != 0 {
+		panic("RecordingTransport.Respond() requires headers as name/value pairs")
+	}
+
+	var b []byte
+	if body != nil {
+		rdr, err := readerOf(body)
+		if err != nil {
+			panic(fmt.Sprintf("RecordingTransport.Respond() body: %v", err))
+		}
+		b, err = io.ReadAll(rdr)
+		if err != nil {
+			panic(fmt.Sprintf("RecordingTransport.Respond() body: %v", err))
+		}
+	}
+
+	h := http.Header{}
+	for i := 0; i < len(header); i += 2 {
+		h.Add(header[i], header[i+1])
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.canned = append(rt.canned, cannedResponse{method: method, path: path, status: status, header: h, body: b})
+	return rt
+}
+
+// Client returns an [http.Client] that uses this transport.
+func (rt *RecordingTransport) Client() *http.Client {
+	return &http.Client{Transport: rt}
+}
+
+// Requests returns a copy of the requests received so far, in order.
+func (rt *RecordingTransport) Requests() []RecordedRequest {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return append([]RecordedRequest(nil), rt.received...)
+}
+
+// RoundTrip implements [http.RoundTripper]. It records the request and returns the first
+// matching canned response.
+func (rt *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := RecordedRequest{Method: req.Method, URL: req.URL, Header: req.Header.Clone(), Body: body}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.received = append(rt.received, recorded)
+
+	canned := cannedResponse{
+		status: http.StatusNotFound,
+		header: http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		body:   []byte(fmt.Sprintf("no canned response for %s %s\n", req.Method, req.URL.RequestURI())),
+	}
+	for _, c := range rt.canned {
+		if (c.method == "" || c.method == req.Method) && (c.path == "" || pathMatches(req.URL, c.path)) {
+			canned = c
+			break
+		}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", canned.status, http.StatusText(canned.status)),
+		StatusCode:    canned.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        canned.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(canned.body)),
+		ContentLength: int64(len(canned.body)),
+		Request:       req,
+	}, nil
+}
+
+func pathMatches(u *url.URL, path string) bool {
+	if strings.Contains(path, "?") {
+		return u.RequestURI() == path
+	}
+	return u.Path == path
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// TransportType is used for assertions about the requests received by a [RecordingTransport].
+type TransportType struct {
+	actual []RecordedRequest
+	assertion
+}
+
+// Transport creates an assertion about the requests received so far by a [RecordingTransport].
+func Transport(rt *RecordingTransport) TransportType {
+	return TransportType{actual: rt.Requests()}
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a TransportType) Info(info any, other ...any) TransportType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a TransportType) I(info any, other ...any) TransportType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a TransportType) Not() TransportType {
+	a.not = !a.not
+	return a
+}
+
+var nRequestsWere = plural.FromZero("no requests were", "only one request was", "only %d requests were")
+
+func (a TransportType) count(method, path string) int {
+	n := 0
+	for _, r := range a.actual {
+		if r.Method == method && pathMatches(r.URL, path) {
+			n++
+		}
+	}
+	return n
+}
+
+func (a TransportType) listing() string {
+	var buf strings.Builder
+	for i, r := range a.actual {
+		fmt.Fprintf(&buf, "%d: %s\n", i, r)
+	}
+	return buf.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveReceived asserts that at least one request was received with the given method and path.
+// If the path contains a query, the query must also match.
+// The tester is normally [*testing.T].
+func (a TransportType) ToHaveReceived(t Tester, method, path string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	found := a.count(method, path) > 0
+
+	if !a.not && !found && len(a.actual) == 0 {
+		a.describeActualExpected1("requests to include %s %s but there were none.\n", method, path)
+	} else if (!a.not && !found) || (a.not && found) {
+		a.describeActualExpectedM("requests ―――\n%s", a.listing())
+		a.addExpectation("to include %s %s.\n", method, path)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveReceivedTimes asserts that exactly n requests were received with the given method and path.
+// If the path contains a query, the query must also match.
+// The tester is normally [*testing.T].
+func (a TransportType) ToHaveReceivedTimes(t Tester, method, path string, n int) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	actual := a.count(method, path)
+
+	if (!a.not && actual != n) || (a.not && actual == n) {
+		a.describeActualExpectedM("requests ―――\n%s", a.listing())
+		a.addExpectation("to include %s %s %s but it was found %s.\n", method, path, nTimes.FormatInt(n), nTimes.FormatInt(actual))
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveReceivedInOrder asserts that the requests include the expected ones in the given order,
+// possibly with other requests between them. Each expected request is written as a method and
+// path, e.g. "GET /items". It is not useful to use [TransportType.Not] with this.
+// The tester is normally [*testing.T].
+func (a TransportType) ToHaveReceivedInOrder(t Tester, expected ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if a.not {
+		panic("Transport().ToHaveReceivedInOrder() does not allow Not() because of ambiguous meaning")
+	}
+
+	i := 0
+	for j, ex := range expected {
+		method, path, _ := strings.Cut(ex, " ")
+		for i < len(a.actual) && !(a.actual[i].Method == method && pathMatches(a.actual[i].URL, path)) {
+			i++
+		}
+
+		if i == len(a.actual) {
+			a.describeActualExpectedM("requests ―――\n%s", a.listing())
+			if j == 0 {
+				a.addExpectation("to include in order ―――\n%s\n――― but %s was not found.\n", strings.Join(expected, "\n"), ex)
+			} else {
+				a.addExpectation("to include in order ―――\n%s\n――― but %s was not found after %s.\n", strings.Join(expected, "\n"), ex, expected[j-1])
+			}
+			a.applyAll(t)
+			return
+		}
+		i++
+	}
+
+	a.passes++
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// Received selects the first request with the given method and path so that assertions can be
+// made about its headers and body.
+func (a TransportType) Received(method, path string) RequestType {
+	for _, r := range a.actual {
+		if r.Method == method && pathMatches(r.URL, path) {
+			return RequestType{actual: r, info: strings.TrimSpace(a.info + " " + method + " " + path)}
+		}
+	}
+	return RequestType{info: strings.TrimSpace(a.info + " " + method + " " + path), fault: "no such request was received"}
+}
+
+// Request selects the i-th request received (counting from zero) so that assertions can be
+// made about its headers and body.
+func (a TransportType) Request(i int) RequestType {
+	if i < 0 || i >= len(a.actual) {
+		return RequestType{info: strings.TrimSpace(fmt.Sprintf("%s request %d", a.info, i)),
+			fault: fmt.Sprintf("%s received", nRequestsWere.FormatInt(len(a.actual)))}
+	}
+	r := a.actual[i]
+	return RequestType{actual: r, info: strings.TrimSpace(a.info + " " + r.Method + " " + r.URL.RequestURI())}
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// RequestType holds one recorded request so that its headers and body can be passed on to
+// other assertions.
+type RequestType struct {
+	actual RecordedRequest
+	info   string
+	fault  string
+}
+
+// Header passes the values of a request header, separated by commas, to a [String] assertion.
+func (r RequestType) Header(name string) *StringType[string] {
+	s := String(strings.Join(r.actual.Header.Values(name), ", ")).Info("header %s of %s", http.CanonicalHeaderKey(name), r.info)
+	s.fault = r.fault
+	return s
+}
+
+// Body passes the request body to a [String] assertion.
+func (r RequestType) Body() *StringType[string] {
+	s := String(string(r.actual.Body)).Info(prefix("body of ", r.info))
+	s.fault = r.fault
+	return s
+}
+
+// JSON passes the request body to a [JSON] assertion.
+func (r RequestType) JSON() JSONType {
+	j := JSON(r.actual.Body).Info(prefix("body of ", r.info))
+	if r.fault != "" {
+		j.fault = r.fault
+	}
+	return j
+}
@@ -0,0 +1,194 @@
+package expect_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func callAPI(t *testing.T) *expect.RecordingTransport {
+	rt := expect.NewRecordingTransport().
+		Respond("GET", "/items", http.StatusOK, `[{"id":1}]`, "Content-Type", "application/json").
+		Respond("POST", "/items", http.StatusCreated, `{"id":2}`)
+
+	client := rt.Client()
+	get := func(path string) {
+		resp, err := client.Get("https://api.example.com" + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	get("/items?page=1")
+	req, _ := http.NewRequest("POST", "https://api.example.com/items", strings.NewReader(`{"name":"pear"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer xyz")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	get("/items")
+	return rt
+}
+
+func TestRecordingTransportResponses(t *testing.T) {
+	c := &capture{}
+
+	rt := expect.NewRecordingTransport().Respond("", "/items", http.StatusOK, "[]", "Content-Type", "application/json")
+
+	resp, err := rt.Client().Get("https://api.example.com/items")
+	expect.Response(resp, err).ToHaveStatus(c, http.StatusOK)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(resp, err).ToHaveContentType(c, "application/json")
+	c.shouldNotHaveHadAnError(t)
+
+	resp, err = rt.Client().Get("https://api.example.com/other")
+	expect.Response(resp, err).ToHaveStatus(c, http.StatusNotFound)
+	c.shouldNotHaveHadAnError(t)
+
+	b, _ := io.ReadAll(resp.Body)
+	expect.String(string(b)).ToBe(c, "no canned response for GET /other\n")
+	c.shouldNotHaveHadAnError(t)
+
+	rt.Respond("DELETE", "/items/1", http.StatusNoContent, nil)
+
+	req, _ := http.NewRequest("DELETE", "https://api.example.com/items/1", nil)
+	resp, err = rt.Client().Do(req)
+	expect.Response(resp, err).ToHaveStatus(c, http.StatusNoContent)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Response(resp, err).Body().ToBeEmpty(c)
+	c.shouldNotHaveHadAnError(t)
+}
+
+func TestTransportToHaveReceived(t *testing.T) {
+	c := &capture{}
+	rt := callAPI(t)
+
+	expect.Transport(rt).ToHaveReceived(c, "GET", "/items")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).ToHaveReceived(c, "GET", "/items?page=1")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).Not().ToHaveReceived(c, "DELETE", "/items")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).I("api").ToHaveReceived(c, "DELETE", "/items/1")
+	c.shouldHaveCalledErrorf(t, `Expected api requests ―――
+0: GET https://api.example.com/items?page=1
+1: POST https://api.example.com/items
+2: GET https://api.example.com/items
+――― to include DELETE /items/1.
+`)
+
+	expect.Transport(rt).Not().ToHaveReceived(c, "POST", "/items")
+	c.shouldHaveCalledErrorf(t, `Expected requests ―――
+0: GET https://api.example.com/items?page=1
+1: POST https://api.example.com/items
+2: GET https://api.example.com/items
+――― not to include POST /items.
+`)
+
+	expect.Transport(expect.NewRecordingTransport()).ToHaveReceived(c, "GET", "/items")
+	c.shouldHaveCalledErrorf(t, "Expected requests to include GET /items but there were none.\n")
+}
+
+func TestTransportToHaveReceivedTimes(t *testing.T) {
+	c := &capture{}
+	rt := callAPI(t)
+
+	expect.Transport(rt).ToHaveReceivedTimes(c, "GET", "/items", 2)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).ToHaveReceivedTimes(c, "POST", "/items", 2)
+	c.shouldHaveCalledErrorf(t, `Expected requests ―――
+0: GET https://api.example.com/items?page=1
+1: POST https://api.example.com/items
+2: GET https://api.example.com/items
+――― to include POST /items 2 times but it was found once.
+`)
+}
+
+func TestTransportToHaveReceivedInOrder(t *testing.T) {
+	c := &capture{}
+	rt := callAPI(t)
+
+	expect.Transport(rt).ToHaveReceivedInOrder(c, "GET /items?page=1", "GET /items")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).ToHaveReceivedInOrder(c, "POST /items", "GET /items?page=1")
+	c.shouldHaveCalledErrorf(t, `Expected requests ―――
+0: GET https://api.example.com/items?page=1
+1: POST https://api.example.com/items
+2: GET https://api.example.com/items
+――― to include in order ―――
+POST /items
+GET /items?page=1
+――― but GET /items?page=1 was not found after POST /items.
+`)
+
+	expect.Transport(rt).ToHaveReceivedInOrder(c, "PUT /items")
+	c.shouldHaveCalledErrorf(t, `Expected requests ―――
+0: GET https://api.example.com/items?page=1
+1: POST https://api.example.com/items
+2: GET https://api.example.com/items
+――― to include in order ―――
+PUT /items
+――― but PUT /items was not found.
+`)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic")
+		}
+	}()
+	expect.Transport(rt).Not().ToHaveReceivedInOrder(c, "GET /items")
+}
+
+func TestTransportReceived(t *testing.T) {
+	c := &capture{}
+	rt := callAPI(t)
+
+	expect.Transport(rt).Received("POST", "/items").Header("authorization").ToBe(c, "Bearer xyz")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).Received("POST", "/items").JSON().ToBeEquivalentTo(c, `{"name": "pear"}`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).Request(2).Body().ToBeEmpty(c)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.Transport(rt).I("api").Received("POST", "/items").Body().ToContain(c, "apple")
+	c.shouldHaveCalledErrorf(t, `Expected body of api POST /items string len:15 ―――
+{"name":"pear"}
+――― to contain ―――
+apple
+`)
+
+	expect.Transport(rt).Received("PUT", "/items").Body().Not().ToBe(c, "x")
+	c.shouldHaveCalledFatalf(t, "Expected body of PUT /items to be usable but no such request was received.\n")
+
+	expect.Transport(rt).Request(3).Header("Accept").Not().ToBe(c, "x")
+	c.shouldHaveCalledFatalf(t, "Expected header Accept of request 3 to be usable but only 3 requests were received.\n")
+}
+
+func ExampleTransport() {
+	var t *testing.T
+
+	rt := expect.NewRecordingTransport().
+		Respond("GET", "/items", http.StatusOK, `[]`, "Content-Type", "application/json")
+
+	// the client under test is given rt.Client(), or uses rt as its Transport
+	client := rt.Client()
+	_, _ = client.Get("https://api.example.com/items")
+
+	expect.Transport(rt).ToHaveReceived(t, "GET", "/items")
+	expect.Transport(rt).Received("GET", "/items").Header("User-Agent").ToContain(t, "Go-http-client")
+}
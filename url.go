@@ -0,0 +1,275 @@
+package expect
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// URLType is used for assertions about URLs.
+type URLType struct {
+	actual *url.URL
+	assertion
+}
+
+// URL creates an assertion about a URL, given either as a [*url.URL] or as a string to be parsed.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func URL[U *url.URL | string](value U, other ...any) URLType {
+	a := URLType{assertion: assertion{otherActual: other}}
+
+	u, err := toURL(value)
+	if err != nil {
+		a.fault = err.Error()
+		u = &url.URL{}
+	}
+
+	a.actual = u
+	return a
+}
+
+func toURL(value any) (*url.URL, error) {
+	switch v := value.(type) {
+	case *url.URL:
+		if v == nil {
+			return nil, fmt.Errorf("the URL is nil")
+		}
+		return v, nil
+	case string:
+		u, err := url.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("it could not be parsed: %v", err)
+		}
+		return u, nil
+	}
+	return nil, fmt.Errorf("%T is not *url.URL or string", value)
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a URLType) Info(info any, other ...any) URLType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a URLType) I(info any, other ...any) URLType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a URLType) Not() URLType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveScheme asserts that the URL has the expected scheme, ignoring case.
+// The tester is normally [*testing.T].
+func (a URLType) ToHaveScheme(t Tester, scheme string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toHavePart(t, "scheme", a.actual.Scheme, scheme, strings.EqualFold(a.actual.Scheme, scheme))
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveHost asserts that the URL has the expected host, ignoring case. If the expected host
+// has no port, the port is not compared; otherwise default ports such as 443 for https are
+// treated as equivalent to no port.
+// The tester is normally [*testing.T].
+func (a URLType) ToHaveHost(t Tester, host string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	var match bool
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		match = normalHost(a.actual) == normalHost(&url.URL{Scheme: a.actual.Scheme, Host: host})
+	} else {
+		match = strings.EqualFold(a.actual.Hostname(), strings.Trim(host, "[]"))
+	}
+
+	a.toHavePart(t, "host", a.actual.Host, host, match)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHavePath asserts that the URL has the expected path. The path is compared after any
+// escapes have been decoded.
+// The tester is normally [*testing.T].
+func (a URLType) ToHavePath(t Tester, path string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.toHavePart(t, "path", a.actual.Path, path, a.actual.Path == path)
+}
+
+func (a URLType) toHavePart(t Tester, part, actual, expected string, match bool) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	if !a.not && !match {
+		a.describeActualExpected1("URL %s to have %s %q but it was %q.\n", a.actual, part, expected, actual)
+	} else if a.not && match {
+		a.describeActualExpected1("URL %s not to have %s %q.\n", a.actual, part, expected)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToHaveQueryParam asserts that the URL has the named query parameter. If any values are
+// specified, the parameter must have exactly these values, in order.
+// The tester is normally [*testing.T].
+func (a URLType) ToHaveQueryParam(t Tester, name string, values ...string) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	actual, present := a.actual.Query()[name]
+
+	match := present
+	if len(values) > 0 {
+		match = slices.Equal(actual, values)
+	}
+
+	switch {
+	case !a.not && !present:
+		a.describeActualExpected1("URL %s to have query parameter %s but it was absent.\n", a.actual, name)
+	case !a.not && !match:
+		a.describeActualExpected1("URL %s to have query parameter %s = %q but it was %q.\n", a.actual, name, values, actual)
+	case a.not && match && len(values) == 0:
+		a.describeActualExpected1("URL %s not to have query parameter %s.\n", a.actual, name)
+	case a.not && match:
+		a.describeActualExpected1("URL %s not to have query parameter %s = %q.\n", a.actual, name, values)
+	default:
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeEquivalentTo asserts that the URL is equivalent to the expected URL, which is a
+// [*url.URL] or a string. The scheme and host are compared ignoring case and default ports
+// (such as 80 for http), an empty path is the same as "/" and the query parameters may be in
+// any order, although repeated values of each parameter must be in the same order.
+// Failures show which parts of the URLs differ.
+// The tester is normally [*testing.T].
+func (a URLType) ToBeEquivalentTo(t Tester, expected any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	ex, err := toURL(expected)
+	if err != nil && a.fault == "" {
+		a.fault = "the expected URL is invalid: " + err.Error()
+		ex = &url.URL{}
+	}
+
+	a.allOtherArgumentsMustNotBeError(t)
+
+	diffs := diffURLs(a.actual, ex)
+
+	if !a.not && len(diffs) > 0 {
+		a.describeActualExpectedM("URL ―――\n%s\n", a.actual)
+		a.addExpectation("to be equivalent to ―――\n%s\n――― but %s ―――\n%s", ex, thereWereNDifferences.FormatInt(len(diffs)), strings.Join(diffs, ""))
+	} else if a.not && len(diffs) == 0 {
+		a.describeActualExpectedM("URL ―――\n%s\n", a.actual)
+		a.addExpectation("to be equivalent to ―――\n%s\n", ex)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func diffURLs(actual, expected *url.URL) []string {
+	var diffs []string
+
+	part := func(name, ac, ex string) {
+		if ac != ex {
+			diffs = append(diffs, fmt.Sprintf("%s: got %q, want %q\n", name, ac, ex))
+		}
+	}
+
+	part("scheme", strings.ToLower(actual.Scheme), strings.ToLower(expected.Scheme))
+	part("user", actual.User.String(), expected.User.String())
+	part("host", normalHost(actual), normalHost(expected))
+	part("path", normalPath(actual), normalPath(expected))
+	part("opaque", actual.Opaque, expected.Opaque)
+
+	acQuery := actual.Query()
+	exQuery := expected.Query()
+	names := make([]string, 0, len(acQuery)+len(exQuery))
+	for name := range acQuery {
+		names = append(names, name)
+	}
+	for name := range exQuery {
+		if _, exists := acQuery[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		ac, inActual := acQuery[name]
+		ex, inExpected := exQuery[name]
+		switch {
+		case !inExpected:
+			diffs = append(diffs, fmt.Sprintf("query %s: got %q, want none\n", name, ac))
+		case !inActual:
+			diffs = append(diffs, fmt.Sprintf("query %s: got none, want %q\n", name, ex))
+		case !slices.Equal(ac, ex):
+			diffs = append(diffs, fmt.Sprintf("query %s: got %q, want %q\n", name, ac, ex))
+		}
+	}
+
+	part("fragment", actual.Fragment, expected.Fragment)
+
+	return diffs
+}
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+// normalHost is the lower-case host with any default port removed.
+func normalHost(u *url.URL) string {
+	host := strings.ToLower(u.Host)
+	if port := u.Port(); port != "" && port == defaultPorts[strings.ToLower(u.Scheme)] {
+		host = strings.TrimSuffix(host, ":"+port)
+	}
+	return host
+}
+
+func normalPath(u *url.URL) string {
+	if u.Path == "" && u.Host != "" {
+		return "/"
+	}
+	return u.Path
+}
@@ -0,0 +1,114 @@
+package expect_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+func TestURLParts(t *testing.T) {
+	c := &capture{}
+
+	const link = "https://Example.com:443/fruit/apple%20pie?size=large&tag=a&tag=b#top"
+
+	expect.URL(link).ToHaveScheme(c, "HTTPS")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(link).ToHaveHost(c, "example.com")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(link).ToHaveHost(c, "example.com:443")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL("http://[::1]:8080/").ToHaveHost(c, "::1")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(link).ToHavePath(c, "/fruit/apple pie")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(link).I("redirect").ToHaveScheme(c, "http")
+	c.shouldHaveCalledErrorf(t, "Expected redirect URL "+link+` to have scheme "http" but it was "https".`+"\n")
+
+	expect.URL(link).ToHaveHost(c, "example.com:8443")
+	c.shouldHaveCalledErrorf(t, "Expected URL "+link+` to have host "example.com:8443" but it was "Example.com:443".`+"\n")
+
+	expect.URL(link).Not().ToHavePath(c, "/fruit/apple pie")
+	c.shouldHaveCalledErrorf(t, "Expected URL "+link+` not to have path "/fruit/apple pie".`+"\n")
+
+	expect.URL("http://a.com/%zz").Not().ToHavePath(c, "/")
+	c.shouldHaveCalledFatalf(t, `Expected to be usable but it could not be parsed: parse "http://a.com/%zz": invalid URL escape "%zz".`+"\n")
+
+	var nilURL *url.URL
+	expect.URL(nilURL).Not().ToHavePath(c, "/")
+	c.shouldHaveCalledFatalf(t, "Expected to be usable but the URL is nil.\n")
+}
+
+func TestURLToHaveQueryParam(t *testing.T) {
+	c := &capture{}
+
+	u, _ := url.Parse("https://example.com/search?q=pear&tag=a&tag=b")
+
+	expect.URL(u).ToHaveQueryParam(c, "q")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(u).ToHaveQueryParam(c, "tag", "a", "b")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(u).Not().ToHaveQueryParam(c, "page")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL(u).ToHaveQueryParam(c, "page")
+	c.shouldHaveCalledErrorf(t, "Expected URL https://example.com/search?q=pear&tag=a&tag=b to have query parameter page but it was absent.\n")
+
+	expect.URL(u).ToHaveQueryParam(c, "tag", "b", "a")
+	c.shouldHaveCalledErrorf(t, `Expected URL https://example.com/search?q=pear&tag=a&tag=b to have query parameter tag = ["b" "a"] but it was ["a" "b"].`+"\n")
+
+	expect.URL(u).Not().ToHaveQueryParam(c, "q")
+	c.shouldHaveCalledErrorf(t, "Expected URL https://example.com/search?q=pear&tag=a&tag=b not to have query parameter q.\n")
+
+	expect.URL(u).Not().ToHaveQueryParam(c, "q", "pear")
+	c.shouldHaveCalledErrorf(t, `Expected URL https://example.com/search?q=pear&tag=a&tag=b not to have query parameter q = ["pear"].`+"\n")
+}
+
+func TestURLToBeEquivalentTo(t *testing.T) {
+	c := &capture{}
+
+	expect.URL("HTTPS://Example.com:443?b=2&a=1&a=3").ToBeEquivalentTo(c, "https://example.com/?a=1&b=2&a=3")
+	c.shouldNotHaveHadAnError(t)
+
+	u, _ := url.Parse("http://example.com:80/x#frag")
+	expect.URL("http://example.com/x#frag").ToBeEquivalentTo(c, u)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.URL("https://example.com:8443/x?a=1&a=3&c=5").I("link").ToBeEquivalentTo(c, "http://example.com/y?a=3&a=1&b=2")
+	c.shouldHaveCalledErrorf(t, `Expected link URL ―――
+https://example.com:8443/x?a=1&a=3&c=5
+――― to be equivalent to ―――
+http://example.com/y?a=3&a=1&b=2
+――― but there were 6 differences ―――
+scheme: got "https", want "http"
+host: got "example.com:8443", want "example.com"
+path: got "/x", want "/y"
+query a: got ["1" "3"], want ["3" "1"]
+query b: got none, want ["2"]
+query c: got ["5"], want none
+`)
+
+	expect.URL("https://example.com/").Not().ToBeEquivalentTo(c, "https://EXAMPLE.com")
+	c.shouldHaveCalledErrorf(t, `Expected URL ―――
+https://example.com/
+――― not to be equivalent to ―――
+https://EXAMPLE.com
+`)
+}
+
+func ExampleURL() {
+	var t *testing.T
+
+	location := "https://example.com/login?next=%2Fhome&lang=en" // e.g. from a redirect
+
+	expect.URL(location).ToHavePath(t, "/login")
+	expect.URL(location).ToHaveQueryParam(t, "next", "/home")
+	expect.URL(location).ToBeEquivalentTo(t, "https://example.com:443/login?lang=en&next=/home")
+}
@@ -0,0 +1,410 @@
+package expect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// XMLType is used for assertions about XML documents.
+type XMLType struct {
+	root   *xmlNode
+	actual *xmlNode
+	attr   *string
+	path   string
+	assertion
+}
+
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     string
+}
+
+// XML creates an assertion about an XML document, which is parsed from a []byte, string
+// or [io.Reader] (including subtypes of []byte and string). Documents are compared in canonical
+// form, i.e. the order of attributes, insignificant whitespace, comments and the choice of
+// namespace prefixes are all ignored.
+//
+// If more than one argument is passed, all subsequent arguments will be required to be nil/zero.
+// This is convenient if you want to make an assertion on a method/function that returns a value and an error,
+// a common pattern in Go.
+func XML(data any, other ...any) XMLType {
+	a := XMLType{assertion: assertion{otherActual: other}}
+	root, err := parseXML(data)
+	if err != nil {
+		a.fault = err.Error()
+	} else {
+		a.root = root
+		a.actual = root
+		a.path = "/" + root.name.Local
+	}
+	return a
+}
+
+// Info adds a description of the assertion to be included in any error message.
+// The first parameter should be some information such as a string or a number or even a struct.
+// If info is a format string, more parameters can follow and will be formatted accordingly (see
+// [fmt.Sprintf]).
+func (a XMLType) Info(info any, other ...any) XMLType {
+	a.info = makeInfo(info, other...)
+	return a
+}
+
+// I is a synonym for [Info].
+func (a XMLType) I(info any, other ...any) XMLType {
+	return a.Info(info, other...)
+}
+
+// Not inverts the assertion.
+func (a XMLType) Not() XMLType {
+	a.not = !a.not
+	return a
+}
+
+//-------------------------------------------------------------------------------------------------
+
+func parseXML(data any) (*xmlNode, error) {
+	r, err := readerOf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := xml.NewDecoder(r)
+
+	var root *xmlNode
+	var stack []*xmlNode
+	var text []string
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("the XML could not be parsed: %w", err)
+		}
+
+		switch tk := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: tk.Name, attrs: canonicalAttrs(tk.Attr)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			} else {
+				return nil, fmt.Errorf("the XML has more than one root element")
+			}
+			stack = append(stack, n)
+			text = append(text, "")
+
+		case xml.EndElement:
+			top := len(stack) - 1
+			stack[top].text = strings.TrimSpace(text[top])
+			stack = stack[:top]
+			text = text[:top]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				text[len(text)-1] += string(tk)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("the XML has no root element")
+	}
+
+	return root, nil
+}
+
+// canonicalAttrs removes namespace declarations (the namespaces have already been resolved)
+// and sorts the remaining attributes.
+func canonicalAttrs(attrs []xml.Attr) []xml.Attr {
+	list := make([]xml.Attr, 0, len(attrs))
+	for _, at := range attrs {
+		if at.Name.Space != "xmlns" && !(at.Name.Space == "" && at.Name.Local == "xmlns") {
+			list = append(list, at)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return xmlNameString(list[i].Name) < xmlNameString(list[j].Name)
+	})
+	return list
+}
+
+func xmlNameString(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return "{" + n.Space + "}" + n.Local
+}
+
+func (n *xmlNode) attr(name xml.Name) (string, bool) {
+	for _, at := range n.attrs {
+		if at.Name == name {
+			return at.Value, true
+		}
+	}
+	return "", false
+}
+
+// canonical renders the node compactly, with sorted attributes and without namespace prefixes.
+func (n *xmlNode) canonical(buf *strings.Builder) {
+	fmt.Fprintf(buf, "<%s", xmlNameString(n.name))
+	for _, at := range n.attrs {
+		fmt.Fprintf(buf, " %s=%q", xmlNameString(at.Name), at.Value)
+	}
+	if len(n.children) == 0 && n.text == "" {
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteByte('>')
+	buf.WriteString(n.text)
+	for _, c := range n.children {
+		c.canonical(buf)
+	}
+	fmt.Fprintf(buf, "</%s>", xmlNameString(n.name))
+}
+
+func (n *xmlNode) String() string {
+	var buf strings.Builder
+	n.canonical(&buf)
+	return buf.String()
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// At selects part of the document using a simple XPath-like path. Path steps are separated by
+// "/" and match element local names; a step can have a 1-based index in square brackets to choose
+// among several elements with the same name, e.g. "items/item[2]/name". A final step "@name"
+// selects an attribute. A path starting with "/" begins with the root element, otherwise it is
+// relative to the currently-selected element. Further assertions then apply to the selection.
+func (a XMLType) At(path string) XMLType {
+	if a.fault != "" {
+		return a
+	}
+
+	steps := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	if strings.HasPrefix(path, "/") {
+		a.actual = a.root
+		a.attr = nil
+		a.path = "/" + a.root.name.Local
+		name, _, _ := parseXMLStep(steps[0])
+		if name != a.root.name.Local {
+			a.path = "/" + steps[0]
+			a.fault = fmt.Sprintf("the root element is <%s>", a.root.name.Local)
+			return a
+		}
+		steps = steps[1:]
+	} else if a.attr != nil {
+		a.fault = fmt.Sprintf("%s is an attribute", a.path)
+		return a
+	}
+
+	for i, step := range steps {
+		if strings.HasPrefix(step, "@") {
+			if i != len(steps)-1 {
+				a.fault = fmt.Sprintf("attribute %s must be the last step of the path", step)
+				return a
+			}
+			name := step[1:]
+			value, found := "", false
+			for _, at := range a.actual.attrs {
+				if at.Name.Local == name {
+					value, found = at.Value, true
+				}
+			}
+			a.path += "/" + step
+			if !found {
+				a.fault = fmt.Sprintf("%s has no attribute %s", a.path[:strings.LastIndexByte(a.path, '/')], name)
+				return a
+			}
+			a.attr = &value
+			return a
+		}
+
+		name, index, err := parseXMLStep(step)
+		if err != "" {
+			a.fault = err
+			return a
+		}
+
+		var matching []*xmlNode
+		for _, c := range a.actual.children {
+			if c.name.Local == name {
+				matching = append(matching, c)
+			}
+		}
+
+		parentPath := a.path
+		a.path += "/" + step
+		if index > len(matching) {
+			if len(matching) == 0 {
+				a.fault = fmt.Sprintf("%s has no element <%s>", parentPath, name)
+			} else {
+				a.fault = fmt.Sprintf("%s has only %d <%s> elements", parentPath, len(matching), name)
+			}
+			return a
+		}
+		a.actual = matching[index-1]
+	}
+
+	return a
+}
+
+func parseXMLStep(step string) (name string, index int, fault string) {
+	open := strings.IndexByte(step, '[')
+	if open < 0 {
+		return step, 1, ""
+	}
+	if !strings.HasSuffix(step, "]") {
+		return "", 0, fmt.Sprintf("path step %q is missing ']'", step)
+	}
+	index, err := strconv.Atoi(step[open+1 : len(step)-1])
+	if err != nil || index < 1 {
+		return "", 0, fmt.Sprintf("path step %q has an invalid index", step)
+	}
+	return step[:open], index, ""
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// String passes the selected attribute value or the text content of the selected element
+// to a [String] assertion.
+func (a XMLType) String() *StringType[string] {
+	s := ""
+	if a.fault != "" {
+		// nothing was selected
+	} else if a.attr != nil {
+		s = *a.attr
+	} else if a.actual != nil {
+		s = a.actual.text
+	}
+	v := String(s, a.otherActual...).Info(a.pathInfo())
+	v.fault = a.fault
+	return v
+}
+
+func (a XMLType) pathInfo() string {
+	if a.info == "" {
+		return "XML" + preS(a.path)
+	}
+	return a.info + " XML" + preS(a.path)
+}
+
+//-------------------------------------------------------------------------------------------------
+
+// ToBeEquivalentTo asserts that the selected element and the expected XML document are the same
+// in canonical form. The expected document can be a []byte, string or [io.Reader]. When they differ,
+// every difference is listed with its path.
+// The tester is normally [*testing.T].
+func (a XMLType) ToBeEquivalentTo(t Tester, expected any) {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	a.info = a.pathInfo()
+	a.allOtherArgumentsMustNotBeError(t)
+
+	ex, err := parseXML(expected)
+	if err != nil {
+		t.Fatal(fmt.Sprintf("Expected%s to be compared with valid XML but %v.\n", preS(a.info), err))
+		return
+	}
+
+	if a.attr != nil {
+		t.Fatal(fmt.Sprintf("Expected%s to be an element but it is an attribute.\n", preS(a.info)))
+		return
+	}
+
+	var diffs []string
+	if a.actual != nil {
+		diffs = diffXML(nil, a.path, ex, a.actual)
+	}
+
+	if !a.not && len(diffs) > 0 {
+		a.describeActualExpected1("to be equivalent but %s ―――\n%s\n",
+			thereWereNDifferences.FormatInt(len(diffs)), strings.Join(diffs, "\n"))
+	} else if a.not && len(diffs) == 0 {
+		a.describeActualExpected1("not to be equivalent to ―――\n%s\n", ex)
+	} else {
+		a.passes++
+	}
+
+	a.applyAll(t)
+}
+
+func diffXML(diffs []string, path string, expected, actual *xmlNode) []string {
+	if expected.name != actual.name {
+		return append(diffs, fmt.Sprintf("%s: got element <%s>, want <%s>",
+			path, xmlNameString(actual.name), xmlNameString(expected.name)))
+	}
+
+	for _, at := range expected.attrs {
+		p := path + "/@" + xmlNameString(at.Name)
+		if v, exists := actual.attr(at.Name); !exists {
+			diffs = append(diffs, fmt.Sprintf("%s: missing, want %q", p, at.Value))
+		} else if v != at.Value {
+			diffs = append(diffs, fmt.Sprintf("%s: got %q, want %q", p, v, at.Value))
+		}
+	}
+
+	for _, at := range actual.attrs {
+		if _, exists := expected.attr(at.Name); !exists {
+			diffs = append(diffs, fmt.Sprintf("%s/@%s: unexpected %q", path, xmlNameString(at.Name), at.Value))
+		}
+	}
+
+	if expected.text != actual.text {
+		diffs = append(diffs, fmt.Sprintf("%s: got text %q, want %q", path, actual.text, expected.text))
+	}
+
+	// align children by name, so that one inserted or removed element does not
+	// cause all its following siblings to differ
+	sameName := func(e, a *xmlNode) bool { return e.name == a.name }
+	for _, e := range editScript(expected.children, actual.children, sameName) {
+		switch e.op {
+		case '-':
+			diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", childPath(path, expected, e.ex), expected.children[e.ex]))
+		case '+':
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", childPath(path, actual, e.ac), actual.children[e.ac]))
+		default:
+			diffs = diffXML(diffs, childPath(path, actual, e.ac), expected.children[e.ex], actual.children[e.ac])
+		}
+	}
+
+	return diffs
+}
+
+// childPath labels the i-th child of parent by its name, plus its 1-based index among
+// same-named siblings when there are several.
+func childPath(path string, parent *xmlNode, i int) string {
+	name := parent.children[i].name.Local
+	p := fmt.Sprintf("%s/%s", path, name)
+	if siblingCount(parent, name) > 1 {
+		index := 0
+		for _, c := range parent.children[:i+1] {
+			if c.name.Local == name {
+				index++
+			}
+		}
+		p = fmt.Sprintf("%s[%d]", p, index)
+	}
+	return p
+}
+
+func siblingCount(parent *xmlNode, name string) int {
+	n := 0
+	for _, c := range parent.children {
+		if c.name.Local == name {
+			n++
+		}
+	}
+	return n
+}
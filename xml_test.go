@@ -0,0 +1,127 @@
+package expect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rickb777/expect"
+)
+
+const xmlDoc = `<?xml version="1.0"?>
+<order id="7" xmlns:p="urn:parts">
+	<!-- a comment -->
+	<customer>Alice</customer>
+	<p:item sku="A1" qty="2">apple</p:item>
+	<p:item sku="B2" qty="1">pear</p:item>
+</order>`
+
+func TestXMLToBeEquivalentTo(t *testing.T) {
+	c := &capture{}
+
+	expect.XML(xmlDoc).ToBeEquivalentTo(c, `<order id="7"><customer> Alice </customer>`+
+		`<x:item xmlns:x="urn:parts" qty="2" sku="A1">apple</x:item>`+
+		`<item xmlns="urn:parts" qty="1" sku="B2">pear</item></order>`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML([]byte(`<a><b/></a>`)).ToBeEquivalentTo(c, strings.NewReader(`<a>
+	<b></b>
+</a>`))
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(`<a><b/></a>`).Not().ToBeEquivalentTo(c, `<a><c/></a>`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).I("response").ToBeEquivalentTo(c, `<order id="8" xmlns:p="urn:parts" status="new">`+
+		`<customer>Bob</customer><p:item sku="A1" qty="3">apple</p:item><p:item sku="B2" qty="1">pear</p:item>`+
+		`<p:item sku="C3" qty="1">plum</p:item></order>`)
+	c.shouldHaveCalledErrorf(t, `Expected response XML /order to be equivalent but there were 5 differences ―――
+/order/@id: got "7", want "8"
+/order/@status: missing, want "new"
+/order/customer: got text "Alice", want "Bob"
+/order/item[1]/@qty: got "2", want "3"
+/order/item[3]: missing, want <{urn:parts}item qty="1" sku="C3">plum</{urn:parts}item>
+`)
+
+	expect.XML(`<a><b/><c x="1"/></a>`).ToBeEquivalentTo(c, `<a><c/></a>`)
+	c.shouldHaveCalledErrorf(t, `Expected XML /a to be equivalent but there were 2 differences ―――
+/a/b: unexpected <b/>
+/a/c/@x: unexpected "1"
+`)
+
+	expect.XML(`<a><x/><b>1</b><c>2</c><b>3</b></a>`).ToBeEquivalentTo(c, `<a><b>1</b><c>2</c><b>4</b></a>`)
+	c.shouldHaveCalledErrorf(t, `Expected XML /a to be equivalent but there were 2 differences ―――
+/a/x: unexpected <x/>
+/a/b[2]: got text "3", want "4"
+`)
+
+	expect.XML(`<a><b/></a>`).Not().ToBeEquivalentTo(c, `<a> <b/> </a>`)
+	c.shouldHaveCalledErrorf(t, "Expected XML /a not to be equivalent to ―――\n<a><b/></a>\n")
+}
+
+func TestXMLInvalid(t *testing.T) {
+	c := &capture{}
+
+	expect.XML(`<a>`).String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML to be usable but the XML could not be parsed: XML syntax error on line 1: unexpected EOF.\n")
+
+	expect.XML(`<a/>`).ToBeEquivalentTo(c, `<a><b></a>`)
+	c.shouldHaveCalledFatalf(t, "Expected XML /a to be compared with valid XML but the XML could not be parsed: XML syntax error on line 1: element <b> closed by </a>.\n")
+
+	expect.XML(``).String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML to be usable but the XML has no root element.\n")
+}
+
+func TestXMLAt(t *testing.T) {
+	c := &capture{}
+
+	expect.XML(xmlDoc).At("customer").String().ToBe(c, "Alice")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).At("/order/item[2]").String().ToBe(c, "pear")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).At("item[2]/@sku").String().ToBe(c, "B2")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).At("@id").At("/order/customer").String().ToBe(c, "Alice")
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).At("item").ToBeEquivalentTo(c, `<item xmlns="urn:parts" sku="A1" qty="2">apple</item>`)
+	c.shouldNotHaveHadAnError(t)
+
+	expect.XML(xmlDoc).At("item[2]/@qty").String().ToBe(c, "2")
+	c.shouldHaveCalledErrorf(t, "Expected XML /order/item[2]/@qty ―――\n1\n――― to be ―――\n2\n――― the first difference is at rune 0.\n")
+}
+
+func TestXMLAtInaccessible(t *testing.T) {
+	c := &capture{}
+
+	expect.XML(xmlDoc).At("item[3]").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /order/item[3] to be usable but /order has only 2 <item> elements.\n")
+
+	expect.XML(xmlDoc).At("customer/name").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /order/customer/name to be usable but /order/customer has no element <name>.\n")
+
+	expect.XML(xmlDoc).At("@colour").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /order/@colour to be usable but /order has no attribute colour.\n")
+
+	expect.XML(xmlDoc).At("/invoice").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /invoice to be usable but the root element is <order>.\n")
+
+	expect.XML(xmlDoc).At("@id/x").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /order to be usable but attribute @id must be the last step of the path.\n")
+
+	expect.XML(xmlDoc).At("item[x]").String().ToBeEmpty(c)
+	c.shouldHaveCalledFatalf(t, "Expected XML /order to be usable but path step \"item[x]\" has an invalid index.\n")
+}
+
+func ExampleXML() {
+	var t *testing.T
+
+	doc := `<order id="7"><item sku="A1">apple</item></order>`
+
+	expect.XML(doc).ToBeEquivalentTo(t, `<order id="7">
+		<item sku="A1">apple</item>
+	</order>`)
+	expect.XML(doc).At("item/@sku").String().ToBe(t, "A1")
+}